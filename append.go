@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/cascax/sql2gorm/parser"
+)
+
+// appendOutput adds the code of sql to the end of an existing file, a type,
+// constant or method declared in the file already is skipped so manual edits
+// are kept. Imports of the added code are merged into the file. A missing file
+// is written as a new file.
+func appendOutput(file string, sql string, opt []parser.Option) error {
+	buf := bytes.Buffer{}
+	if err := parser.ParseSqlToWrite(sql, &buf, opt...); err != nil {
+		return err
+	}
+	old, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return ioutil.WriteFile(file, buf.Bytes(), 0666)
+	}
+	if err != nil {
+		return err
+	}
+	code, err := parser.AppendCode(old, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("\r\n")) {
+		// the code is formatted again with LF
+		code = bytes.ReplaceAll(bytes.ReplaceAll(code, []byte("\r\n"), []byte("\n")), []byte("\n"), []byte("\r\n"))
+	}
+	return ioutil.WriteFile(file, code, 0666)
+}
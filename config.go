@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// loadConfig sets flags from a YAML or JSON file, keys are names of flags like
+// "json-style" and a list sets a repeated flag. Flags in command line win over
+// the file.
+func loadConfig(fs *flag.FlagSet, file string) error {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{})
+	// JSON is YAML as well
+	if err := yaml.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%s: %v", file, err)
+	}
+	inCommand := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) {
+		inCommand[f.Name] = true
+	})
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "config" || fs.Lookup(name) == nil {
+			return fmt.Errorf("%s: unknown flag %s", file, name)
+		}
+		if inCommand[name] || values[name] == nil {
+			continue
+		}
+		list, ok := values[name].([]interface{})
+		if !ok {
+			list = []interface{}{values[name]}
+		}
+		for _, v := range list {
+			if err := fs.Set(name, fmt.Sprint(v)); err != nil {
+				return fmt.Errorf("%s: invalid value %v of %s: %v", file, v, name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/cascax/sql2gorm/parser"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// diffOutput writes a unified diff between the code and the existing file to
+// w, it reports whether they are different. A missing file is empty.
+func diffOutput(w io.Writer, file string, sql string, opt []parser.Option) (bool, error) {
+	buf := bytes.Buffer{}
+	if err := parser.ParseSqlToWrite(sql, &buf, opt...); err != nil {
+		return false, err
+	}
+	old, err := ioutil.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	if bytes.Equal(old, buf.Bytes()) {
+		return false, nil
+	}
+	diff := difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(old)),
+		B:        difflib.SplitLines(buf.String()),
+		FromFile: file,
+		ToFile:   file + " (generated)",
+		Context:  3,
+	}
+	return true, difflib.WriteUnifiedDiff(w, diff)
+}
@@ -3,14 +3,15 @@ package main
 import (
 	"bytes"
 	"embed"
+	"errors"
 	"flag"
 	"fmt"
 	"html/template"
-	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/cascax/sql2gorm/parser"
 	"github.com/gin-gonic/gin"
@@ -20,23 +21,138 @@ type options struct {
 	Charset        string
 	Collation      string
 	JsonTag        bool
+	BsonTag        bool
+	DBTag          bool
+	YamlTag        bool
+	Annotations    bool
+	JsonTagStyle   string
+	GraphQLStyle   string
+	JsonOmitEmpty  bool
 	TablePrefix    string
+	TableSuffix    string
 	ColumnPrefix   string
 	NoNullType     bool
+	NullAccessors  bool
+	DefaultPtrs    bool
+	Unexported     bool
+	OmitColumn     bool
+	ExplicitColumn bool
+	Repository     bool
+	SizeTag        bool
 	NullStyle      string
 	Package        string
 	GormType       bool
 	ForceTableName bool
+	NoTableName    bool
+	SchemaInName   bool
+	TableNameConst bool
+	IncludeViews   bool
+	Dialect        string
+	Associations   bool
+	AssocPointers  bool
+	ExternalPkg    string
+	TablePkgs      stringList
+	IndexTags      bool
+	Comments       bool
+	CommentTags    bool
+	ORM            string
+	SoftDelete     bool
+	GormTimestamps bool
+	GormModel      bool
+	TypeMapping    stringList
+	UnsignedTypes  bool
+	TinyIntBool    bool
+	TimeDuration   bool
+	DecimalType    string
+	JSONDatatype   bool
+	JSONScanner    bool
+	EnumConstants  bool
+	ValidateTag    bool
+	Target         string
+	DefaultTags    bool
+	SingularStruct bool
+	StructNames    stringList
+	StructPrefix   string
+	TemplateFile   string
+	FileHeader     stringList
+	FieldOrder     string
+	UUIDType       string
+	SpatialType    string
+	UnknownType    string
+	UUIDColumns    stringList
+	ExcludeColumns stringList
+	Embed          stringList
+	StringMethod   bool
+	Constructor    bool
+	AutoMigrate    string
+	ColumnsHelper  bool
+	LineEnding     string
+	PqArrays       bool
+	PrimaryKeyType string
+	Redact         stringList
+	NullColumns    stringList
 
-	InputFile  string
+	ConfigFile string
+	InputFiles fileList
 	OutputFile string
+	OutputDir  string
+	Check      bool
+	Watch      bool
+	Diff       bool
+	Append     bool
 	Sql        string
 
 	MysqlDsn   string
 	MysqlTable string
+	AllTables  bool
 
 	Serve        bool
 	ServeAddress string
+
+	Version bool
+}
+
+// stringList is a flag which can be repeated
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// fileList is a flag of files which can be repeated or comma separated
+type fileList []string
+
+func (l *fileList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *fileList) Set(s string) error {
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// flexBool is a bool in JSON which accepts "true" and "false" strings as well
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true", "1":
+		*b = true
+	case "false", "0", "", "null":
+		*b = false
+	default:
+		return fmt.Errorf("invalid bool value: %s", data)
+	}
+	return nil
 }
 
 func exitWithInfo(format string, a ...interface{}) {
@@ -48,34 +164,132 @@ func parseFlag() options {
 	args := options{}
 	// flagSet := flag.NewFlagSet("optional", flag.ExitOnError)
 
-	flag.StringVar(&args.InputFile, "f", "", "input file")
+	flag.StringVar(&args.ConfigFile, "config", "", "YAML or JSON file of flags, e.g. json-style: camel, flags in command line win")
+	flag.Var(&args.InputFiles, "f", "input file, can be repeated, comma separated or a glob like schema/*.sql, - for stdin")
 	flag.StringVar(&args.OutputFile, "o", "", "output file")
+	flag.StringVar(&args.OutputDir, "out-dir", "", "output directory, write a file for each table")
 	flag.StringVar(&args.Sql, "sql", "", "input SQL")
+	flag.BoolVar(&args.Diff, "diff", false, "print diff between the code and the file of -o instead of writing, exit with 1 if they differ")
+	flag.BoolVar(&args.Append, "append", false, "add structs not in the file of -o to its end, the rest of the file is kept")
+	flag.BoolVar(&args.Watch, "watch", false, "generate again when input files(-f) change")
+	flag.StringVar(&args.LineEnding, "line-ending", "", "line ending of output: lf or crlf, default: lf")
+	flag.BoolVar(&args.Check, "check", false, "check that SQL is parsed and code is formatted, write nothing")
 
 	flag.BoolVar(&args.JsonTag, "json", false, "generate json tag")
+	flag.BoolVar(&args.BsonTag, "bson", false, "generate bson tag")
+	flag.BoolVar(&args.DBTag, "db-tag", false, "generate db tag of column name for sqlx")
+	flag.BoolVar(&args.YamlTag, "yaml", false, "generate yaml tag")
+	flag.StringVar(&args.JsonTagStyle, "json-style", "", "name style of json tag: column, camel or snake, default: column")
+	flag.BoolVar(&args.JsonOmitEmpty, "json-omitempty", false, "add omitempty to json tag of nullable columns")
 	flag.StringVar(&args.TablePrefix, "table-prefix", "", "table name prefix")
+	flag.StringVar(&args.TableSuffix, "table-suffix", "", "table name suffix")
 	flag.StringVar(&args.ColumnPrefix, "col-prefix", "", "column name prefix")
 	flag.BoolVar(&args.NoNullType, "no-null", false, "do not use Null type")
 	flag.StringVar(
 		&args.NullStyle, "null-style", "",
 		"null type: sql.NullXXX(use 'sql') or *xxx(use 'ptr')",
 	)
+	flag.BoolVar(&args.DefaultPtrs, "default-ptr", false, "use pointers for NOT NULL columns with default values, gorm inserts the default for nil")
+	flag.BoolVar(&args.NullAccessors, "null-accessors", false, "write GetXXX and SetXXX methods for null fields")
+	flag.BoolVar(&args.Unexported, "unexported", false, "unexported field names, gorm and encoding/json ignore them")
+	flag.BoolVar(&args.OmitColumn, "omit-column", false, "omit column of gorm tag if gorm names the field the same")
+	flag.BoolVar(&args.ExplicitColumn, "explicit-column", false, "write column of gorm tag for every field, the default, it wins over -omit-column")
+	flag.BoolVar(&args.SizeTag, "with-size", false, "write size of varchar and type of text in gorm tag")
+	flag.BoolVar(&args.Repository, "repository", false, "write a repository interface and its gorm implementation for each table")
 	flag.StringVar(&args.Package, "pkg", "", "package name, default: model")
 	flag.BoolVar(&args.GormType, "with-type", false, "write type in gorm tag")
 	flag.BoolVar(&args.ForceTableName, "with-tablename", false, "write TableName func force")
+	flag.BoolVar(&args.NoTableName, "no-tablename", false, "never write TableName func, gorm names the table, it wins over -with-tablename")
+	flag.BoolVar(&args.SchemaInName, "tablename-schema", false, "keep the schema of a name like shop.users in TableName func")
+	flag.BoolVar(&args.TableNameConst, "tablename-const", false, "declare a constant of table name like UserTableName")
+	flag.BoolVar(&args.IncludeViews, "include-views", false, "write read only structs of CREATE VIEW, views are skipped by default")
+	flag.StringVar(&args.FieldOrder, "order", "", "order of fields: column, name or pk, default: column")
+	flag.Var(&args.StructNames, "struct-name", "struct name of table, e.g. tbl_usr:User, can be repeated")
+	flag.StringVar(&args.StructPrefix, "struct-prefix", "", "prefix of struct names, e.g. PG for PGUser")
+	flag.BoolVar(&args.SingularStruct, "singular", false, "use singular struct name, e.g. User for table users")
+	flag.BoolVar(&args.Associations, "assoc", false, "write belongs to fields of foreign keys")
+	flag.BoolVar(&args.DefaultTags, "with-default", false, "write quoted default value in gorm tag for auto migration")
+	flag.BoolVar(&args.AssocPointers, "assoc-ptr", false, "use pointers for fields of -assoc")
+	flag.StringVar(&args.ExternalPkg, "ext-pkg", "", "import path of structs of -assoc tables not in the input")
+	flag.Var(&args.TablePkgs, "table-pkg", "import path of struct of -assoc table, e.g. orders:example.com/shop/order, can be repeated")
+	flag.BoolVar(&args.IndexTags, "with-index", false, "write index in gorm tag")
+	flag.BoolVar(&args.Annotations, "annotations", false, "read @gotype, @json, @gorm and @skip in column comment")
+	flag.BoolVar(&args.Comments, "doc-comment", false, "write column comment above the field")
+	flag.BoolVar(&args.CommentTags, "with-comment", false, "write column comment in gorm tag")
+	flag.StringVar(&args.ORM, "orm", "", "tag of ORM: gorm or xorm, default: gorm")
+	flag.BoolVar(&args.SoftDelete, "soft-delete", false, "use gorm.DeletedAt for deleted_at")
+	flag.BoolVar(&args.GormModel, "gorm-model", false, "embed gorm.Model for id, created_at, updated_at and deleted_at")
+	flag.BoolVar(&args.GormTimestamps, "gorm-timestamps", false, "follow gorm conventions for created_at and updated_at")
+	flag.Var(
+		&args.TypeMapping, "type-map",
+		"map SQL type to Go type, e.g. decimal=github.com/shopspring/decimal.Decimal, can be repeated",
+	)
+	flag.StringVar(&args.PrimaryKeyType, "pk-type", "", "go type of auto increment primary keys, default: uint")
+	flag.Var(&args.ExcludeColumns, "exclude", "skip columns matching the pattern, e.g. etl_*, can be repeated")
+	flag.Var(&args.Embed, "embed", "embed columns of the prefix in a struct of gorm, e.g. address, can be repeated")
+	flag.BoolVar(&args.StringMethod, "string-method", false, "write String() of structs for logging")
+	flag.BoolVar(&args.Constructor, "constructor", false, "write a constructor like NewUser setting the default values of columns")
+	flag.StringVar(&args.AutoMigrate, "auto-migrate", "", "write a function of the name migrating all generated models of gorm, e.g. AutoMigrate")
+	flag.BoolVar(&args.ColumnsHelper, "columns-helper", false, "write column names of fields like UserColumns.Email and Columns() of structs")
+	flag.Var(&args.Redact, "redact", "hide columns matching the pattern in String(), e.g. password, can be repeated")
+	flag.Var(&args.NullColumns, "null-col", "use null types for columns matching the pattern even if NOT NULL, e.g. description, can be repeated")
+	flag.BoolVar(&args.TinyIntBool, "tinyint-bool", false, "use bool for tinyint(1) columns")
+	flag.BoolVar(&args.TimeDuration, "time-duration", false, "use time.Duration for time columns")
+	flag.BoolVar(&args.UnsignedTypes, "unsigned", false, "use sized unsigned types like uint32 for unsigned columns")
+	flag.StringVar(&args.DecimalType, "decimal", "", "go type of decimal columns, e.g. github.com/shopspring/decimal.Decimal")
+	flag.BoolVar(&args.JSONDatatype, "json-datatype", false, "use datatypes.JSON of gorm.io/datatypes for json columns")
+	flag.BoolVar(&args.JSONScanner, "json-scanner", false, "write Scan and Value of the Go type of json columns, which is set by -annotations or -type-map")
+	flag.StringVar(&args.UUIDType, "uuid", "", "go type of binary(16) and char(36) columns, e.g. github.com/google/uuid.UUID")
+	flag.StringVar(&args.SpatialType, "spatial-type", "", "go type of spatial columns like point and geometry, default: []byte of WKB")
+	flag.BoolVar(&args.PqArrays, "pq-arrays", false, "use types of github.com/lib/pq like pq.StringArray for postgres arrays instead of slices")
+	flag.StringVar(&args.UnknownType, "unknown-type", "", "go type of columns of unknown types like a postgres enum, e.g. interface{}, default: string")
+	flag.Var(&args.UUIDColumns, "uuid-col", "only columns matching the pattern are UUID with -uuid, e.g. *_id, can be repeated")
+	flag.BoolVar(&args.EnumConstants, "enum-const", false, "declare a string type with constants for enum columns")
+	flag.BoolVar(&args.ValidateTag, "validate", false, "generate validate tag of go-playground/validator")
+	flag.Var(&args.FileHeader, "header", "line before the package clause, e.g. //go:build mysql, can be repeated")
+	flag.StringVar(&args.TemplateFile, "template", "", "template file of text/template to write each struct")
+	flag.StringVar(&args.Target, "target", "", "output: go, ts, ent(schema of entgo.io), proto, json-schema or graphql, default: go")
+	flag.StringVar(&args.GraphQLStyle, "graphql-style", "", "name style of graphql fields: column, camel or snake, default: camel")
+	flag.StringVar(&args.Dialect, "dialect", "", "SQL dialect: mysql(or mariadb), postgres, sqlite or sqlserver, default: mysql")
+	flag.StringVar(&args.Charset, "charset", "", "character set of string columns if the SQL omits it, written with -with-type")
+	flag.StringVar(&args.Collation, "collation", "", "collation of string columns if the SQL omits it, written with -with-type")
 
-	flag.StringVar(&args.MysqlDsn, "db-dsn", "", "mysql dsn([user]:[pass]@/[database][?charset=xxx&...])")
-	flag.StringVar(&args.MysqlTable, "db-table", "", "mysql table name")
+	flag.StringVar(&args.MysqlDsn, "db-dsn", "", "mysql dsn([user]:[pass]@/[database][?charset=xxx&...]) or postgres url(postgres://...)")
+	flag.StringVar(&args.MysqlTable, "db-table", "", "table name")
+	flag.BoolVar(&args.AllTables, "db-all-tables", false, "get every table of the database instead of -db-table, views are excluded")
 
 	flag.BoolVar(&args.Serve, "serve", false, "serve web page")
 	flag.StringVar(&args.ServeAddress, "serve-address", ":18080", "serve port")
 
+	flag.BoolVar(&args.Version, "version", false, "print version and exit")
+
 	flag.Parse()
+	if args.Version {
+		fmt.Println(versionString())
+		os.Exit(0)
+	}
+	if args.ConfigFile != "" {
+		if err := loadConfig(flag.CommandLine, args.ConfigFile); err != nil {
+			exitWithInfo("%v", err)
+		}
+	}
 	return args
 }
 
 func getOptions(args options) []parser.Option {
 	opt := make([]parser.Option, 0, 1)
+	if args.Sql == "" && len(args.InputFiles) > 0 {
+		names := make([]string, 0, len(args.InputFiles))
+		for _, f := range args.InputFiles {
+			if f == "-" {
+				f = "stdin"
+			}
+			names = append(names, f)
+		}
+		opt = append(opt, parser.WithSource(strings.Join(names, ", ")))
+	} else if args.Sql == "" && args.MysqlTable != "" {
+		opt = append(opt, parser.WithSource("table "+args.MysqlTable))
+	}
 	if args.Charset != "" {
 		opt = append(opt, parser.WithCharset(args.Charset))
 	}
@@ -85,9 +299,48 @@ func getOptions(args options) []parser.Option {
 	if args.JsonTag {
 		opt = append(opt, parser.WithJsonTag())
 	}
+	if args.Annotations {
+		opt = append(opt, parser.WithAnnotations())
+	}
+	if args.YamlTag {
+		opt = append(opt, parser.WithYamlTag())
+	}
+	if args.BsonTag {
+		opt = append(opt, parser.WithBsonTag())
+	}
+	if args.DBTag {
+		opt = append(opt, parser.WithDBTag())
+	}
+	if args.JsonOmitEmpty {
+		opt = append(opt, parser.WithJsonOmitEmpty())
+	}
+	if args.NullAccessors {
+		opt = append(opt, parser.WithNullAccessors())
+	}
+	if args.DefaultPtrs {
+		opt = append(opt, parser.WithPointersForDefaults())
+	}
+	if args.Unexported {
+		opt = append(opt, parser.WithUnexportedFields())
+	}
+	if args.OmitColumn {
+		opt = append(opt, parser.WithOmitRedundantColumnTag())
+	}
+	if args.ExplicitColumn {
+		opt = append(opt, parser.WithExplicitColumnTags())
+	}
+	if args.Repository {
+		opt = append(opt, parser.WithRepository())
+	}
+	if args.SizeTag {
+		opt = append(opt, parser.WithSizeTag())
+	}
 	if args.TablePrefix != "" {
 		opt = append(opt, parser.WithTablePrefix(args.TablePrefix))
 	}
+	if args.TableSuffix != "" {
+		opt = append(opt, parser.WithTableSuffix(args.TableSuffix))
+	}
 	if args.ColumnPrefix != "" {
 		opt = append(opt, parser.WithColumnPrefix(args.ColumnPrefix))
 	}
@@ -114,6 +367,259 @@ func getOptions(args options) []parser.Option {
 	if args.ForceTableName {
 		opt = append(opt, parser.WithForceTableName())
 	}
+	if args.NoTableName {
+		opt = append(opt, parser.WithNoTableName())
+	}
+	if args.SchemaInName {
+		opt = append(opt, parser.WithSchemaInTableName())
+	}
+	if args.TableNameConst {
+		opt = append(opt, parser.WithTableNameConst())
+	}
+	if args.IncludeViews {
+		opt = append(opt, parser.WithIncludeViews())
+	}
+	if args.Associations {
+		opt = append(opt, parser.WithAssociations())
+	}
+	if args.AssocPointers {
+		opt = append(opt, parser.WithAssociationPointers())
+	}
+	if args.IndexTags {
+		opt = append(opt, parser.WithIndexTags())
+	}
+	if args.Comments {
+		opt = append(opt, parser.WithComments())
+	}
+	if args.CommentTags {
+		opt = append(opt, parser.WithCommentTags())
+	}
+	if args.SoftDelete {
+		opt = append(opt, parser.WithSoftDelete())
+	}
+	if args.GormTimestamps {
+		opt = append(opt, parser.WithGormTimestamps())
+	}
+	if args.GormModel {
+		opt = append(opt, parser.WithGormModel())
+	}
+	if args.ValidateTag {
+		opt = append(opt, parser.WithValidateTag())
+	}
+	if args.DefaultTags {
+		opt = append(opt, parser.WithDefaultTags())
+	}
+	if args.SingularStruct {
+		opt = append(opt, parser.WithSingularStruct())
+	}
+	if args.EnumConstants {
+		opt = append(opt, parser.WithEnumConstants())
+	}
+	if len(args.ExcludeColumns) > 0 {
+		opt = append(opt, parser.WithExcludeColumns(args.ExcludeColumns...))
+	}
+	if len(args.Embed) > 0 {
+		opt = append(opt, parser.WithEmbeddedGroups(args.Embed...))
+	}
+	if args.PrimaryKeyType != "" {
+		opt = append(opt, parser.WithPrimaryKeyType(args.PrimaryKeyType))
+	}
+	if args.StringMethod {
+		opt = append(opt, parser.WithStringMethod())
+	}
+	if args.Constructor {
+		opt = append(opt, parser.WithConstructor())
+	}
+	if args.AutoMigrate != "" {
+		opt = append(opt, parser.WithAutoMigrateFunc(args.AutoMigrate))
+	}
+	if args.ColumnsHelper {
+		opt = append(opt, parser.WithColumnsHelper())
+	}
+	if len(args.Redact) > 0 {
+		opt = append(opt, parser.WithRedactedColumns(args.Redact...))
+	}
+	if len(args.NullColumns) > 0 {
+		opt = append(opt, parser.WithNullColumns(args.NullColumns...))
+	}
+	if args.TinyIntBool {
+		opt = append(opt, parser.WithTinyIntBool())
+	}
+	if args.TimeDuration {
+		opt = append(opt, parser.WithTimeAsDuration())
+	}
+	if args.JSONDatatype {
+		opt = append(opt, parser.WithJSONDatatype())
+	}
+	if args.JSONScanner {
+		opt = append(opt, parser.WithJSONScanner())
+	}
+	if args.PqArrays {
+		opt = append(opt, parser.WithPqArrays())
+	}
+	if args.UnknownType != "" {
+		opt = append(opt, parser.WithUnknownTypeFallback(args.UnknownType))
+	}
+	if args.SpatialType != "" {
+		opt = append(opt, parser.WithSpatialType(args.SpatialType))
+	}
+	if args.UUIDType != "" {
+		opt = append(opt, parser.WithUUIDType(args.UUIDType, args.UUIDColumns...))
+	}
+	if args.DecimalType != "" {
+		opt = append(opt, parser.WithDecimalType(args.DecimalType))
+	}
+	if args.UnsignedTypes {
+		opt = append(opt, parser.WithUnsignedTypes())
+	}
+	if len(args.TypeMapping) > 0 {
+		m := make(map[string]string, len(args.TypeMapping))
+		for _, s := range args.TypeMapping {
+			i := strings.LastIndexByte(s, '=')
+			if i <= 0 {
+				fmt.Printf("invalid type mapping: %s\n", s)
+				return nil
+			}
+			m[s[:i]] = s[i+1:]
+		}
+		opt = append(opt, parser.WithTypeMapping(m))
+	}
+	if args.ExternalPkg != "" {
+		opt = append(opt, parser.WithExternalPackage(args.ExternalPkg))
+	}
+	if len(args.TablePkgs) > 0 {
+		m := make(map[string]string, len(args.TablePkgs))
+		for _, s := range args.TablePkgs {
+			i := strings.IndexByte(s, ':')
+			if i <= 0 || i == len(s)-1 {
+				fmt.Printf("invalid table package: %s\n", s)
+				return nil
+			}
+			m[s[:i]] = s[i+1:]
+		}
+		opt = append(opt, parser.WithTablePackageMap(m))
+	}
+	if len(args.FileHeader) > 0 {
+		opt = append(opt, parser.WithFileHeader(args.FileHeader...))
+	}
+	if args.TemplateFile != "" {
+		tmpl, err := ioutil.ReadFile(args.TemplateFile)
+		if err != nil {
+			fmt.Printf("read template failed, %s\n", err)
+			return nil
+		}
+		opt = append(opt, parser.WithTemplate(string(tmpl)))
+	}
+	if args.StructPrefix != "" {
+		opt = append(opt, parser.WithStructNamePrefix(args.StructPrefix))
+	}
+	if len(args.StructNames) > 0 {
+		m := make(map[string]string, len(args.StructNames))
+		for _, s := range args.StructNames {
+			i := strings.LastIndexByte(s, ':')
+			if i <= 0 || i == len(s)-1 {
+				fmt.Printf("invalid struct name: %s\n", s)
+				return nil
+			}
+			m[s[:i]] = s[i+1:]
+		}
+		opt = append(opt, parser.WithStructNameMap(m))
+	}
+	if args.Target != "" {
+		switch args.Target {
+		case "go":
+			opt = append(opt, parser.WithTarget(parser.TargetGo))
+		case "ts":
+			opt = append(opt, parser.WithTarget(parser.TargetTypeScript))
+		case "ent":
+			opt = append(opt, parser.WithTarget(parser.TargetEnt))
+		case "proto":
+			opt = append(opt, parser.WithTarget(parser.TargetProto))
+		case "json-schema":
+			opt = append(opt, parser.WithTarget(parser.TargetJSONSchema))
+		case "graphql":
+			opt = append(opt, parser.WithTarget(parser.TargetGraphQL))
+		default:
+			fmt.Printf("invalid target: %s\n", args.Target)
+			return nil
+		}
+	}
+	if args.GraphQLStyle != "" {
+		switch args.GraphQLStyle {
+		case "column":
+			opt = append(opt, parser.WithGraphQLFieldStyle(parser.JsonKeepColumn))
+		case "camel":
+			opt = append(opt, parser.WithGraphQLFieldStyle(parser.JsonCamelCase))
+		case "snake":
+			opt = append(opt, parser.WithGraphQLFieldStyle(parser.JsonSnakeCase))
+		default:
+			fmt.Printf("invalid graphql style: %s\n", args.GraphQLStyle)
+			return nil
+		}
+	}
+	if args.JsonTagStyle != "" {
+		switch args.JsonTagStyle {
+		case "column":
+			opt = append(opt, parser.WithJsonTagStyle(parser.JsonKeepColumn))
+		case "camel":
+			opt = append(opt, parser.WithJsonTagStyle(parser.JsonCamelCase))
+		case "snake":
+			opt = append(opt, parser.WithJsonTagStyle(parser.JsonSnakeCase))
+		default:
+			fmt.Printf("invalid json style: %s\n", args.JsonTagStyle)
+			return nil
+		}
+	}
+	if args.FieldOrder != "" {
+		switch args.FieldOrder {
+		case "column":
+			opt = append(opt, parser.WithFieldOrder(parser.OrderDeclaration))
+		case "name":
+			opt = append(opt, parser.WithFieldOrder(parser.OrderAlphabetical))
+		case "pk":
+			opt = append(opt, parser.WithFieldOrder(parser.OrderPrimaryKeyFirst))
+		default:
+			fmt.Printf("invalid field order: %s\n", args.FieldOrder)
+			return nil
+		}
+	}
+	if args.ORM != "" {
+		switch args.ORM {
+		case "gorm":
+			opt = append(opt, parser.WithORM(parser.ORMGorm))
+		case "xorm":
+			opt = append(opt, parser.WithORM(parser.ORMXorm))
+		default:
+			fmt.Printf("invalid orm: %s\n", args.ORM)
+			return nil
+		}
+	}
+	if args.LineEnding != "" {
+		switch args.LineEnding {
+		case "lf":
+			opt = append(opt, parser.WithLineEnding("\n"))
+		case "crlf":
+			opt = append(opt, parser.WithLineEnding("\r\n"))
+		default:
+			fmt.Printf("invalid line ending: %s\n", args.LineEnding)
+			return nil
+		}
+	}
+	if args.Dialect != "" {
+		switch args.Dialect {
+		case "mysql", "mariadb":
+			opt = append(opt, parser.WithDialect(parser.DialectMySQL))
+		case "postgres":
+			opt = append(opt, parser.WithDialect(parser.DialectPostgres))
+		case "sqlite":
+			opt = append(opt, parser.WithDialect(parser.DialectSQLite))
+		case "sqlserver", "mssql":
+			opt = append(opt, parser.WithDialect(parser.DialectSQLServer))
+		default:
+			fmt.Printf("invalid dialect: %s\n", args.Dialect)
+			return nil
+		}
+	}
 	return opt
 }
 
@@ -121,6 +627,8 @@ func getOptions(args options) []parser.Option {
 var FS embed.FS
 
 func main() {
+	// warnings of parser are written to stderr without time
+	log.SetFlags(0)
 	args := parseFlag()
 
 	if args.Serve {
@@ -128,36 +636,73 @@ func main() {
 		return
 	}
 
-	var output io.Writer
-	if args.OutputFile != "" {
-		f, err := os.OpenFile(args.OutputFile, os.O_CREATE|os.O_WRONLY, 0666)
-		if err != nil {
-			exitWithInfo("open %s failed, %s\n", args.OutputFile, err)
+	if args.OutputFile != "" && args.OutputDir != "" {
+		exitWithInfo("-o and -out-dir can't be used together")
+	}
+	if args.Watch {
+		if len(args.InputFiles) == 0 {
+			exitWithInfo("-watch needs input files(-f)")
 		}
-		defer f.Close()
-		output = f
-	} else {
-		output = os.Stdout
+		if isInputFile(args.InputFiles, "-") {
+			exitWithInfo("-watch can't read stdin")
+		}
+		opt := getOptions(args)
+		if opt == nil {
+			os.Exit(1)
+		}
+		watch(args, opt)
+		return
 	}
 	sql := args.Sql
+	if sql == "" && len(args.InputFiles) == 0 && args.MysqlDsn == "" && stdinPiped() {
+		// e.g. mysqldump ... | sql2gorm
+		args.InputFiles = fileList{"-"}
+	}
+	var files parser.SqlFiles
 	if sql == "" {
-		if args.InputFile != "" {
-			b, err := ioutil.ReadFile(args.InputFile)
+		if len(args.InputFiles) > 0 {
+			var err error
+			files, err = parser.ReadSqlFiles(args.InputFiles)
 			if err != nil {
-				exitWithInfo("read %s failed, %s\n", args.InputFile, err)
+				exitWithInfo("read SQL failed, %s\n", err)
 			}
-			sql = string(b)
+			sql = files.Sql
 		} else if args.MysqlDsn != "" {
-			if args.MysqlTable == "" {
+			if args.MysqlTable == "" && !args.AllTables {
 				exitWithInfo("miss mysql table")
 			}
+			if args.MysqlTable != "" && args.AllTables {
+				exitWithInfo("-db-table can't be used with -db-all-tables")
+			}
 			var err error
-			sql, err = parser.GetCreateTableFromDB(args.MysqlDsn, args.MysqlTable)
+			var creates []string
+			isPostgres := parser.IsPostgresDsn(args.MysqlDsn)
+			switch {
+			case args.AllTables && isPostgres:
+				creates, err = parser.GetAllCreateTablesFromPostgres(args.MysqlDsn)
+			case args.AllTables:
+				creates, err = parser.GetAllCreateTablesFromDB(args.MysqlDsn)
+			case isPostgres:
+				sql, err = parser.GetCreateTableFromPostgres(args.MysqlDsn, args.MysqlTable)
+			default:
+				sql, err = parser.GetCreateTableFromDB(args.MysqlDsn, args.MysqlTable)
+			}
 			if err != nil {
 				exitWithInfo("get create table error: %s", err)
 			}
+			if args.AllTables {
+				if len(creates) == 0 {
+					exitWithInfo("no table in the database")
+				}
+				for _, s := range creates {
+					sql += strings.TrimRight(strings.TrimSpace(s), ";") + ";\n"
+				}
+			}
+			if isPostgres && args.Dialect == "" {
+				args.Dialect = "postgres"
+			}
 		} else {
-			_, _ = fmt.Fprintf(os.Stderr, "no SQL input(-sql|-f|-db-dsn)\n\n")
+			_, _ = fmt.Fprintf(os.Stderr, "no SQL input(-sql|-f|-db-dsn|stdin)\n\n")
 			flag.Usage()
 			os.Exit(2)
 		}
@@ -165,15 +710,67 @@ func main() {
 
 	opt := getOptions(args)
 	if opt == nil {
+		os.Exit(1)
+	}
+
+	if args.Diff {
+		if args.OutputFile == "" {
+			exitWithInfo("-diff needs the output file(-o)")
+		}
+		differ, err := diffOutput(os.Stdout, args.OutputFile, sql, opt)
+		if err != nil {
+			exitWithInfo(files.Locate(err).Error())
+		}
+		if differ {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if args.Append {
+		if args.OutputFile == "" {
+			exitWithInfo("-append needs the output file(-o)")
+		}
+		if err := appendOutput(args.OutputFile, sql, opt); err != nil {
+			exitWithInfo(files.Locate(err).Error())
+		}
+		return
+	}
+
+	if args.Check {
+		// parse and format without writing anything
+		if err := parser.ParseSqlToWrite(sql, ioutil.Discard, opt...); err != nil {
+			exitWithInfo(files.Locate(err).Error())
+		}
 		return
 	}
 
-	err := parser.ParseSqlToWrite(sql, output, opt...)
+	var err error
+	if args.OutputDir != "" {
+		err = parser.ParseSqlToFiles(sql, args.OutputDir, opt...)
+	} else if args.OutputFile != "" {
+		// replaces the whole file, a shorter output leaves no old tail
+		buf := bytes.Buffer{}
+		err = parser.ParseSqlToWrite(sql, &buf, opt...)
+		if buf.Len() > 0 || err == nil {
+			if werr := ioutil.WriteFile(args.OutputFile, buf.Bytes(), 0666); werr != nil {
+				exitWithInfo("write %s failed, %s\n", args.OutputFile, werr)
+			}
+		}
+	} else {
+		err = parser.ParseSqlToWrite(sql, os.Stdout, opt...)
+	}
 	if err != nil {
-		exitWithInfo(err.Error())
+		exitWithInfo(files.Locate(err).Error())
 	}
 }
 
+// stdinPiped reports whether stdin is a pipe or a file rather than a terminal
+func stdinPiped() bool {
+	fi, err := os.Stdin.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice == 0
+}
+
 func serve(args options) {
 	engine := gin.Default()
 	tmpl := template.Must(template.New("").ParseFS(FS, "public/*.html"))
@@ -187,27 +784,31 @@ func serve(args options) {
 	engine.POST(
 		"/api/parse", func(ctx *gin.Context) {
 			var req = struct {
-				ColPrefix      string `json:"col_prefix"`
-				Json           string `json:"json"`
-				TablePrefix    string `json:"table_prefix"`
-				Package        string `json:"package"`
-				NoNull         string `json:"no_null"`
-				NullStyle      string `json:"null_style"`
-				GormType       string `json:"gorm_type"`
-				ForceTableName string `json:"force_tablename"`
-				Sql            string `json:"sql"`
+				ColPrefix      string   `json:"col_prefix"`
+				Json           flexBool `json:"json"`
+				TablePrefix    string   `json:"table_prefix"`
+				Package        string   `json:"package"`
+				NoNull         flexBool `json:"no_null"`
+				NullStyle      string   `json:"null_style"`
+				GormType       flexBool `json:"gorm_type"`
+				ForceTableName flexBool `json:"force_tablename"`
+				Sql            string   `json:"sql"`
 			}{}
 
 			err := ctx.BindJSON(&req)
 			if err != nil {
 				return
 			}
+			if strings.TrimSpace(req.Sql) == "" {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "sql is empty"})
+				return
+			}
 
 			opt := make([]parser.Option, 0, 1)
 			if req.ColPrefix != "" {
 				opt = append(opt, parser.WithColumnPrefix(req.ColPrefix))
 			}
-			if req.Json == "true" {
+			if req.Json {
 				opt = append(opt, parser.WithJsonTag())
 			}
 			if req.TablePrefix != "" {
@@ -216,7 +817,7 @@ func serve(args options) {
 			if req.Package != "" {
 				opt = append(opt, parser.WithPackage(req.Package))
 			}
-			if req.NoNull == "true" {
+			if req.NoNull {
 				opt = append(opt, parser.WithNoNullType())
 			}
 			if req.NullStyle != "" {
@@ -227,21 +828,29 @@ func serve(args options) {
 					opt = append(opt, parser.WithNullStyle(parser.NullInPointer))
 				default:
 					ctx.JSON(
-						http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid null style: %s\n", req.NullStyle)},
+						http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid null style: %s", req.NullStyle)},
 					)
+					return
 				}
 			}
-			if req.GormType == "true" {
+			if req.GormType {
 				opt = append(opt, parser.WithGormType())
 			}
-			if req.ForceTableName == "true" {
+			if req.ForceTableName {
 				opt = append(opt, parser.WithForceTableName())
 			}
 
 			buf := bytes.NewBuffer([]byte{})
 			err = parser.ParseSqlToWrite(req.Sql, buf, opt...)
 			if err != nil {
-				ctx.JSON(http.StatusBadRequest, gin.H{"error": err})
+				resp := gin.H{"error": err.Error()}
+				var parseErr *parser.ParseError
+				if errors.As(err, &parseErr) {
+					resp["message"] = parseErr.Message
+					resp["line"] = parseErr.Line
+					resp["column"] = parseErr.Column
+				}
+				ctx.JSON(http.StatusBadRequest, resp)
 				return
 			}
 
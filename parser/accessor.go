@@ -0,0 +1,57 @@
+package parser
+
+import "strings"
+
+// tmplAccessor is the getter and setter of a nullable field
+type tmplAccessor struct {
+	Getter string
+	Setter string
+	Field  string
+	Type   string // type of value
+	Value  string // value field of sql.NullXXX, empty for pointer
+	Null   string // sql.NullXXX
+}
+
+// sqlNullValues are value fields of sql.NullXXX
+var sqlNullValues = map[string]struct{ Field, Type string }{
+	"sql.NullString":  {"String", "string"},
+	"sql.NullInt64":   {"Int64", "int64"},
+	"sql.NullInt32":   {"Int32", "int32"},
+	"sql.NullFloat64": {"Float64", "float64"},
+	"sql.NullBool":    {"Bool", "bool"},
+	"sql.NullTime":    {"Time", "time.Time"},
+}
+
+// makeAccessors makes GetXXX and SetXXX for fields of sql.NullXXX and pointer,
+// a method named after a field or another method gets suffix "Value".
+func makeAccessors(fields []tmplField) []tmplAccessor {
+	used := map[string]struct{}{"TableName": {}}
+	for _, f := range fields {
+		used[f.Name] = struct{}{}
+	}
+	methodName := func(name string) string {
+		if _, ok := used[name]; ok {
+			name += "Value"
+		}
+		used[name] = struct{}{}
+		return name
+	}
+
+	accessors := make([]tmplAccessor, 0)
+	for _, f := range fields {
+		a := tmplAccessor{Field: f.Name}
+		if v, ok := sqlNullValues[f.GoType]; ok {
+			a.Type, a.Value, a.Null = v.Type, v.Field, f.GoType
+		} else if strings.HasPrefix(f.GoType, "*") {
+			a.Type = f.GoType[1:]
+		} else {
+			continue
+		}
+		// the field may be unexported
+		name := strings.ToUpper(f.Name[:1]) + f.Name[1:]
+		a.Getter = methodName("Get" + name)
+		a.Setter = methodName("Set" + name)
+		accessors = append(accessors, a)
+	}
+	return accessors
+}
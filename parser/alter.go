@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/ast"
+)
+
+// alterTable applies ALTER TABLE to its table created before, columns and
+// indexes are added, changed or dropped. Other changes are ignored.
+func alterTable(tables []TableInfo, stmt *ast.AlterTableStmt, hints tableHints, ctx *parseContext) {
+	var table *TableInfo
+	for i := len(tables) - 1; i >= 0; i-- {
+		if strings.EqualFold(tables[i].Name, stmt.Table.Name.O) {
+			table = &tables[i]
+			break
+		}
+	}
+	if table == nil {
+		ctx.warn(stmt.Table.Name.O, "", "ALTER TABLE is skipped, the table is not created before")
+		return
+	}
+	for _, spec := range stmt.Specs {
+		switch spec.Tp {
+		case ast.AlterTableAddColumns:
+			pos := spec.Position
+			for _, col := range spec.NewColumns {
+				c, keys := newColumnInfo(col, false, hints)
+				insertColumn(table, c, pos)
+				table.ForeignKeys = append(table.ForeignKeys, keys...)
+				if pos != nil && pos.Tp != ast.ColumnPositionNone {
+					// the next column follows it
+					pos = &ast.ColumnPosition{Tp: ast.ColumnPositionAfter, RelativeColumn: col.Name}
+				}
+			}
+		case ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+			if len(spec.NewColumns) == 0 {
+				continue
+			}
+			oldName := spec.NewColumns[0].Name.Name.O
+			if spec.OldColumnName != nil {
+				oldName = spec.OldColumnName.Name.O
+			}
+			i := findColumn(table, oldName)
+			if i < 0 {
+				continue
+			}
+			c, keys := newColumnInfo(spec.NewColumns[0], table.Columns[i].PrimaryKey, hints)
+			if spec.Position == nil || spec.Position.Tp == ast.ColumnPositionNone {
+				table.Columns[i] = c
+			} else {
+				table.Columns = append(table.Columns[:i], table.Columns[i+1:]...)
+				insertColumn(table, c, spec.Position)
+			}
+			renameColumn(table, oldName, c.Name)
+			table.ForeignKeys = append(table.ForeignKeys, keys...)
+		case ast.AlterTableDropColumn:
+			if spec.OldColumnName != nil {
+				dropColumn(table, spec.OldColumnName.Name.O)
+			}
+		case ast.AlterTableAddConstraint:
+			con := spec.Constraint
+			if con.Tp == ast.ConstraintForeignKey && con.Refer != nil {
+				cols := make([]string, 0, len(con.Keys))
+				for _, k := range con.Keys {
+					cols = append(cols, k.Column.Name.String())
+				}
+				table.ForeignKeys = append(table.ForeignKeys, newForeignKey(cols, con.Refer))
+				continue
+			}
+			idx, ok := newIndexInfo(con)
+			if !ok {
+				continue
+			}
+			if idx.Primary {
+				for _, name := range idx.Columns {
+					if i := findColumn(table, name); i >= 0 {
+						c := &table.Columns[i]
+						c.PrimaryKey, c.Nullable, c.nullDeclared = true, false, false
+					}
+				}
+			}
+			table.Indexes = append(table.Indexes, idx)
+		case ast.AlterTableDropIndex:
+			indexes := table.Indexes[:0]
+			for _, idx := range table.Indexes {
+				if !strings.EqualFold(idx.Name, spec.Name) {
+					indexes = append(indexes, idx)
+				}
+			}
+			table.Indexes = indexes
+		}
+	}
+}
+
+// findColumn finds a column by name case insensitive, it's -1 if not found.
+func findColumn(t *TableInfo, name string) int {
+	for i, c := range t.Columns {
+		if strings.EqualFold(c.Name, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertColumn adds a column at FIRST or AFTER a column, or at the end
+func insertColumn(t *TableInfo, c ColumnInfo, pos *ast.ColumnPosition) {
+	i := len(t.Columns)
+	if pos != nil {
+		switch pos.Tp {
+		case ast.ColumnPositionFirst:
+			i = 0
+		case ast.ColumnPositionAfter:
+			if j := findColumn(t, pos.RelativeColumn.Name.O); j >= 0 {
+				i = j + 1
+			}
+		}
+	}
+	t.Columns = append(t.Columns, ColumnInfo{})
+	copy(t.Columns[i+1:], t.Columns[i:])
+	t.Columns[i] = c
+}
+
+// renameColumn renames a column in indexes and foreign keys
+func renameColumn(t *TableInfo, oldName, newName string) {
+	rename := func(cols []string) {
+		for i, c := range cols {
+			if strings.EqualFold(c, oldName) {
+				cols[i] = newName
+			}
+		}
+	}
+	for _, idx := range t.Indexes {
+		rename(idx.Columns)
+	}
+	for _, key := range t.ForeignKeys {
+		rename(key.Columns)
+	}
+}
+
+// dropColumn removes a column, it's removed from indexes as MySQL does and
+// an index without columns is dropped with foreign keys of the column.
+func dropColumn(t *TableInfo, name string) {
+	i := findColumn(t, name)
+	if i < 0 {
+		return
+	}
+	t.Columns = append(t.Columns[:i], t.Columns[i+1:]...)
+	indexes := t.Indexes[:0]
+	for _, idx := range t.Indexes {
+		cols := idx.Columns[:0]
+		for _, c := range idx.Columns {
+			if !strings.EqualFold(c, name) {
+				cols = append(cols, c)
+			}
+		}
+		idx.Columns = cols
+		if len(cols) > 0 {
+			indexes = append(indexes, idx)
+		}
+	}
+	t.Indexes = indexes
+	keys := t.ForeignKeys[:0]
+	for _, key := range t.ForeignKeys {
+		if !containsFold(key.Columns, name) {
+			keys = append(keys, key)
+		}
+	}
+	t.ForeignKeys = keys
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package parser
+
+import "strings"
+
+// columnAnnotation is set by directives in column comment with WithAnnotations
+type columnAnnotation struct {
+	GoType string // Go type with import path
+	Json   string // name in json tag
+	Gorm   string // the whole gorm tag
+	Skip   bool
+}
+
+// parseAnnotations gets directives like "@json:name" from a comment, the
+// comment is returned without them. A directive ends at a space.
+func parseAnnotations(comment string) (columnAnnotation, string) {
+	var ann columnAnnotation
+	if !strings.Contains(comment, "@") {
+		return ann, comment
+	}
+	lines := strings.Split(comment, "\n")
+	for i, line := range lines {
+		words := strings.Fields(line)
+		kept := words[:0]
+		for _, w := range words {
+			key, value := w, ""
+			if j := strings.IndexByte(w, ':'); j >= 0 {
+				key, value = w[:j], w[j+1:]
+			}
+			switch strings.ToLower(key) {
+			case "@gotype":
+				ann.GoType = value
+			case "@json":
+				ann.Json = value
+			case "@gorm":
+				ann.Gorm = value
+			case "@skip":
+				ann.Skip = true
+			default:
+				kept = append(kept, w)
+			}
+		}
+		if len(kept) < len(words) {
+			lines[i] = strings.Join(kept, " ")
+		}
+	}
+	return ann, strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// applyAnnotations parses annotations of columns, skipped columns are removed
+func applyAnnotations(table *TableInfo) {
+	cols := table.Columns[:0]
+	for _, col := range table.Columns {
+		col.ann, col.Comment = parseAnnotations(col.Comment)
+		if col.ann.Skip {
+			continue
+		}
+		cols = append(cols, col)
+	}
+	table.Columns = cols
+}
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/format"
+	goparser "go/parser"
+	gotoken "go/token"
+	"strconv"
+	"strings"
+)
+
+// AppendCode appends declarations of the generated src to the old file, a
+// type, constant or method declared in the file already is skipped, e.g. a
+// struct of table is added once. Imports used by the added code are merged.
+func AppendCode(old, src []byte) ([]byte, error) {
+	oldSet := gotoken.NewFileSet()
+	oldFile, err := goparser.ParseFile(oldSet, "", old, goparser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	srcSet := gotoken.NewFileSet()
+	srcFile, err := goparser.ParseFile(srcSet, "", src, goparser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	declared := declaredNames(oldFile)
+	imported := make(map[string]struct{})
+	for _, imp := range oldFile.Imports {
+		imported[imp.Path.Value] = struct{}{}
+	}
+	skippedTypes := make(map[string]struct{})
+	// the added code
+	added := bytes.Buffer{}
+	used := make(map[string]struct{})
+	for _, decl := range srcFile.Decls {
+		switch decl := decl.(type) {
+		case *goast.GenDecl:
+			if decl.Tok == gotoken.IMPORT {
+				continue
+			}
+			if skip := genDeclared(decl, declared); skip {
+				if decl.Tok == gotoken.TYPE {
+					for _, spec := range decl.Specs {
+						skippedTypes[spec.(*goast.TypeSpec).Name.Name] = struct{}{}
+					}
+				}
+				continue
+			}
+		case *goast.FuncDecl:
+			recv := receiverName(decl)
+			if _, ok := skippedTypes[recv]; ok {
+				continue
+			}
+			if _, ok := declared[recv+"."+decl.Name.Name]; ok {
+				continue
+			}
+		}
+		start := decl.Pos()
+		if doc := declDoc(decl); doc != nil {
+			start = doc.Pos()
+		}
+		added.WriteString("\n")
+		added.Write(src[srcSet.Position(start).Offset:srcSet.Position(decl.End()).Offset])
+		added.WriteString("\n")
+		goast.Inspect(decl, func(n goast.Node) bool {
+			if sel, ok := n.(*goast.SelectorExpr); ok {
+				if id, ok := sel.X.(*goast.Ident); ok {
+					used[id.Name] = struct{}{}
+				}
+			}
+			return true
+		})
+	}
+	if added.Len() == 0 {
+		return old, nil
+	}
+
+	var imports []string
+	for _, imp := range srcFile.Imports {
+		if _, ok := imported[imp.Path.Value]; ok {
+			continue
+		}
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := importName(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if _, ok := used[name]; ok {
+			imports = append(imports, imp.Path.Value)
+		}
+	}
+
+	code := bytes.Buffer{}
+	code.Write(insertImports(old, oldSet, oldFile, imports))
+	code.Write(added.Bytes())
+	return format.Source(code.Bytes())
+}
+
+// declaredNames collects top level names of a file, a method is named like
+// User.TableName.
+func declaredNames(file *goast.File) map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *goast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *goast.TypeSpec:
+					names[spec.Name.Name] = struct{}{}
+				case *goast.ValueSpec:
+					for _, n := range spec.Names {
+						names[n.Name] = struct{}{}
+					}
+				}
+			}
+		case *goast.FuncDecl:
+			if recv := receiverName(decl); recv != "" {
+				names[recv+"."+decl.Name.Name] = struct{}{}
+			} else {
+				names[decl.Name.Name] = struct{}{}
+			}
+		}
+	}
+	return names
+}
+
+// genDeclared reports whether a name of the type, const or var declaration
+// is declared already.
+func genDeclared(decl *goast.GenDecl, declared map[string]struct{}) bool {
+	for _, spec := range decl.Specs {
+		switch spec := spec.(type) {
+		case *goast.TypeSpec:
+			if _, ok := declared[spec.Name.Name]; ok {
+				return true
+			}
+		case *goast.ValueSpec:
+			for _, n := range spec.Names {
+				if _, ok := declared[n.Name]; ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// receiverName is the type name of receiver of a method, empty for a func
+func receiverName(decl *goast.FuncDecl) string {
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return ""
+	}
+	tp := decl.Recv.List[0].Type
+	if star, ok := tp.(*goast.StarExpr); ok {
+		tp = star.X
+	}
+	if id, ok := tp.(*goast.Ident); ok {
+		return id.Name
+	}
+	return ""
+}
+
+func declDoc(decl goast.Decl) *goast.CommentGroup {
+	switch decl := decl.(type) {
+	case *goast.GenDecl:
+		return decl.Doc
+	case *goast.FuncDecl:
+		return decl.Doc
+	}
+	return nil
+}
+
+// insertImports adds import paths to the first import declaration of the
+// file, a declaration is added after the package clause if the file has none.
+func insertImports(src []byte, fset *gotoken.FileSet, file *goast.File, paths []string) []byte {
+	if len(paths) == 0 {
+		return src
+	}
+	lines := "\t" + strings.Join(paths, "\n\t") + "\n"
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*goast.GenDecl)
+		if !ok || gen.Tok != gotoken.IMPORT {
+			continue
+		}
+		if gen.Rparen.IsValid() {
+			at := fset.Position(gen.Rparen).Offset
+			return joinBytes(src[:at], []byte(lines), src[at:])
+		}
+		// import "fmt" becomes a declaration in parentheses
+		start, end := fset.Position(gen.Specs[0].Pos()).Offset, fset.Position(gen.Specs[0].End()).Offset
+		spec := "(\n\t" + string(src[start:end]) + "\n" + lines + ")"
+		return joinBytes(src[:start], []byte(spec), src[end:])
+	}
+	at := fset.Position(file.Name.End()).Offset
+	return joinBytes(src[:at], []byte("\n\nimport (\n"+lines+")"), src[at:])
+}
+
+func joinBytes(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
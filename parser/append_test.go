@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendCode(t *testing.T) {
+	old := `package model
+
+import "fmt"
+
+// Users is edited
+type Users struct {
+	ID   int
+	Note string
+}
+
+func (m *Users) Hello() string { return fmt.Sprint(m.ID) }
+`
+	sql := `CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE orders (id int PRIMARY KEY, paid_at datetime NOT NULL);`
+	buf := bytes.Buffer{}
+	if !assert.NoError(t, ParseSqlToWrite(sql, &buf, WithForceTableName())) {
+		return
+	}
+	code, err := AppendCode([]byte(old), buf.Bytes())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// Users is edited
+type Users struct {
+	ID   int
+	Note string
+}
+
+func (m *Users) Hello() string { return fmt.Sprint(m.ID) }
+
+type Orders struct {
+	ID     int       `+"`gorm:\"column:id;primaryKey\"`"+`
+	PaidAt time.Time `+"`gorm:\"column:paid_at;NOT NULL\"`"+`
+}
+
+func (m *Orders) TableName() string {
+	return "orders"
+}
+`, string(code))
+
+	// nothing is added at the second time
+	again, err := AppendCode(code, buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, string(code), string(again))
+}
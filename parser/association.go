@@ -0,0 +1,77 @@
+package parser
+
+import (
+	"strings"
+)
+
+// makeAssociations makes a belongs to field for every foreign key of the table
+// and returns import paths of referenced structs in other packages. fieldNames
+// maps column names to the field names of the struct.
+func makeAssociations(table TableInfo, fieldNames map[string]string, ctx *parseContext, opt options) ([]tmplField, []string) {
+	keys := table.ForeignKeys
+	fields := make([]tmplField, 0, len(keys))
+	var importPath []string
+	usedNames := make(map[string]struct{}, len(fieldNames))
+	for _, n := range fieldNames {
+		usedNames[n] = struct{}{}
+	}
+	for _, key := range keys {
+		_, inInput := ctx.tables[strings.ToLower(key.RefTable)]
+		refPkg, ok := opt.TablePackages[strings.ToLower(key.RefTable)]
+		if !ok && !inInput {
+			refPkg = opt.ExternalPackage
+		}
+		if !inInput && refPkg == "" {
+			ctx.warn(table.Name, "", "it references table %s which is not in the input", key.RefTable)
+		}
+		refStruct := structName(key.RefTable, opt)
+
+		// user_id makes field User, or it's named by the referenced struct
+		var name, jsonKey string
+		if col := fieldNames[key.Columns[0]]; len(key.Columns) == 1 && strings.HasSuffix(col, "ID") && len(col) > 2 {
+			name = col[:len(col)-2]
+			jsonKey = toSnake(name)
+		} else {
+			name = refStruct
+			jsonKey = trimTableName(key.RefTable, opt)
+		}
+		if _, ok := usedNames[name]; ok {
+			name += "Ref"
+			jsonKey += "_ref"
+		}
+		usedNames[name] = struct{}{}
+
+		goType := refStruct
+		if refPkg != "" {
+			goType = importName(refPkg) + "." + refStruct
+			importPath = append(importPath, refPkg)
+		}
+		if opt.AssociationPointers || strings.EqualFold(key.RefTable, table.Name) {
+			// a struct can't contain itself, it must be a pointer
+			goType = "*" + goType
+		}
+
+		foreignFields := make([]string, 0, len(key.Columns))
+		for _, c := range key.Columns {
+			foreignFields = append(foreignFields, fieldNames[c])
+		}
+		gormTag := "foreignKey:" + strings.Join(foreignFields, ",")
+		if len(key.RefColumns) > 0 {
+			refFields := make([]string, 0, len(key.RefColumns))
+			for _, c := range key.RefColumns {
+				refFields = append(refFields, toCamel(trimColumnPrefix(c, opt)))
+			}
+			gormTag += ";references:" + strings.Join(refFields, ",")
+		}
+		tags := []string{"gorm", gormTag}
+		if opt.JsonTag {
+			tags = append(tags, "json", jsonName(jsonKey, opt))
+		}
+		fields = append(fields, tmplField{
+			Name:   name,
+			GoType: goType,
+			Tag:    makeTagStr(tags),
+		})
+	}
+	return fields, importPath
+}
@@ -0,0 +1,57 @@
+package parser
+
+import (
+	"strings"
+)
+
+// CheckInfo is a CHECK constraint of table
+type CheckInfo struct {
+	Name    string   // empty if the constraint is not named
+	Expr    string   // condition in source, e.g. "age >= 0"
+	Columns []string // columns in the condition
+}
+
+// readCheck reads CHECK (expr) [[NOT] ENFORCED] starting at i, names in the
+// condition are kept as columns until they are resolved with the table.
+func readCheck(elem []token, i int, sql string) (CheckInfo, int) {
+	check := CheckInfo{}
+	group := findGroup(elem, i)
+	if len(group) < 3 {
+		return check, skipGroup(elem, i+1)
+	}
+	// a condition in several lines is written in a line of comment
+	check.Expr = strings.Join(strings.Fields(sql[group[1].pos:group[len(group)-2].end]), " ")
+	for j := 1; j < len(group)-1; j++ {
+		if group[j].isName() && !group[j+1].isSymbol("(") {
+			check.Columns = append(check.Columns, group[j].text)
+		}
+	}
+	i = skipGroup(elem, i+1)
+	if i < len(elem) && elem[i].is("NOT") && i+1 < len(elem) && elem[i+1].is("ENFORCED") {
+		i += 2
+	} else if i < len(elem) && elem[i].is("ENFORCED") {
+		i++
+	}
+	return check, i
+}
+
+// resolveChecks keeps names of columns in conditions in the order of table
+func resolveChecks(checks []CheckInfo, columns []ColumnInfo) []CheckInfo {
+	if len(checks) == 0 {
+		return nil
+	}
+	for n, check := range checks {
+		names := make(map[string]struct{}, len(check.Columns))
+		for _, c := range check.Columns {
+			names[strings.ToLower(c)] = struct{}{}
+		}
+		check.Columns = nil
+		for _, col := range columns {
+			if _, ok := names[strings.ToLower(col.Name)]; ok {
+				check.Columns = append(check.Columns, col.Name)
+			}
+		}
+		checks[n] = check
+	}
+	return checks
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"text/template"
+)
+
+var columnsTmpl = template.Must(template.New("columns").Parse(`
+// {{.Model}}Columns are the column names of {{.Model}}, e.g. for a query
+// built without reflect
+var {{.Model}}Columns = struct {
+{{- range .Columns}}
+	{{.Field}} string
+{{- end}}
+}{
+{{- range .Columns}}
+	{{.Field}}: {{printf "%q" .Name}},
+{{- end}}
+}
+
+// Columns returns the column names of {{.Model}} in the order of fields
+func (m {{.Model}}) Columns() []string {
+	return []string{
+{{- range .Columns}}
+		{{$.Model}}Columns.{{.Field}},
+{{- end}}
+	}
+}
+`))
+
+type tmplColumns struct {
+	Model   string
+	Columns []tmplColumn
+}
+
+// tmplColumn is a column of a field, Field is exported even if the field of
+// model isn't, a field of embedded struct is named like AddressStreet.
+type tmplColumn struct {
+	Field string
+	Name  string
+}
+
+// makeColumns makes the column helpers of model, it's nil if a field of model
+// is named Columns or there is no column.
+func makeColumns(model string, fields []tmplField, columns []tmplColumn) *tmplColumns {
+	if len(columns) == 0 {
+		return nil
+	}
+	for _, f := range fields {
+		if f.Name == "Columns" {
+			return nil
+		}
+	}
+	return &tmplColumns{Model: model, Columns: columns}
+}
@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+var constructorTmpl = template.Must(template.New("constructor").Parse(`
+// New{{.Model}} returns a {{.Model}} with the default values of columns
+func New{{.Model}}() *{{.Model}} {
+{{- if .Fields}}
+	return &{{.Model}}{
+{{- range .Fields}}
+		{{.Name}}: {{.Value}},
+{{- end}}
+	}
+{{- else}}
+	return &{{.Model}}{}
+{{- end}}
+}
+`))
+
+type tmplConstructor struct {
+	Model  string
+	Fields []tmplDefaultField
+}
+
+// tmplDefaultField is a field set by the constructor, Value is a Go literal
+type tmplDefaultField struct {
+	Name  string
+	Value string
+}
+
+// defaultLiteral gets the Go literal of the default value of a column. It's
+// false for a zero value, a function or expression like CURRENT_TIMESTAMP, or
+// a type a literal can't be written for, e.g. a pointer or time.Time.
+func defaultLiteral(col ColumnInfo, goType string) (string, bool) {
+	if !col.HasDefault || col.hint.Default != "" {
+		return "", false
+	}
+	def := col.Default
+	nullType, nullValue := "", ""
+	if v, ok := sqlNullValues[goType]; ok {
+		nullType, nullValue, goType = goType, v.Field, v.Type
+	}
+	var value string
+	switch goType {
+	case "string":
+		if !col.DefaultIsString {
+			// a number rather than a function like CURRENT_USER
+			if _, err := strconv.ParseFloat(def, 64); err != nil {
+				return "", false
+			}
+		}
+		if def == "" {
+			return "", false
+		}
+		value = strconv.Quote(def)
+	case "bool":
+		b, err := strconv.ParseBool(strings.ToLower(def))
+		if err != nil || !b {
+			return "", false
+		}
+		value = "true"
+	case "int", "int8", "int16", "int32", "int64":
+		n, err := strconv.ParseInt(def, 10, 64)
+		if err != nil || n == 0 {
+			return "", false
+		}
+		value = strconv.FormatInt(n, 10)
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		n, err := strconv.ParseUint(def, 10, 64)
+		if err != nil || n == 0 {
+			return "", false
+		}
+		value = strconv.FormatUint(n, 10)
+	case "float32", "float64":
+		f, err := strconv.ParseFloat(def, 64)
+		if err != nil || f == 0 {
+			return "", false
+		}
+		value = def
+	default:
+		return "", false
+	}
+	if nullType != "" {
+		value = nullType + "{" + nullValue + ": " + value + ", Valid: true}"
+	}
+	return value, true
+}
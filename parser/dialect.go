@@ -0,0 +1,933 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/parser"
+)
+
+// Dialect is the SQL dialect of the input. Statements of dialects other than
+// MySQL are translated to MySQL before parsing, anything but CREATE TABLE,
+// CREATE INDEX and ALTER TABLE is dropped during translation.
+type Dialect int
+
+const (
+	DialectMySQL Dialect = iota
+	DialectPostgres
+	DialectSQLite
+	DialectSQLServer
+)
+
+// columnHint keeps what the source dialect says about a column but the
+// translated MySQL statement can't express.
+type columnHint struct {
+	RawType   string // declared type in lower case, e.g. "timestamptz", "text[]"
+	ArrayDims int
+	GoType    string // used as is instead of the mapped type when not empty
+	Unknown   bool   // the type isn't known, it's translated to text
+	Default   string // an expression of DEFAULT the parser doesn't support, e.g. uuid()
+
+	DefaultFsp  string // fsp of the current time in DEFAULT, e.g. "3" of CURRENT_TIMESTAMP(3)
+	OnUpdateFsp string // fsp of the current time in ON UPDATE
+}
+
+// tableHints keeps what the source says about a table but the parser drops.
+type tableHints struct {
+	columns map[string]columnHint // by lower case column names
+	checks  []CheckInfo
+}
+
+type declaredType struct {
+	Name      string   // lower case words, e.g. "character varying"
+	Args      []string // e.g. ["10", "2"] for numeric(10,2)
+	ArrayDims int
+}
+
+func (t declaredType) String() string {
+	s := t.Name
+	if len(t.Args) > 0 {
+		s += "(" + strings.Join(t.Args, ",") + ")"
+	}
+	return s + strings.Repeat("[]", t.ArrayDims)
+}
+
+// translatedType is a declared type mapped to MySQL.
+type translatedType struct {
+	Mysql         string
+	GoType        string
+	AutoIncrement bool
+	Unknown       bool // the type isn't known, it's mapped to text
+}
+
+type dialectSpec struct {
+	lexer      lexerConfig
+	preprocess func(sql string) string
+	mapType    func(t declaredType) translatedType
+	fixColumn  func(c *ddlColumn)
+	// INDEX name (columns) is an element of CREATE TABLE
+	tableIndexes bool
+}
+
+var dialects = map[Dialect]*dialectSpec{
+	DialectPostgres:  &postgresDialect,
+	DialectSQLite:    &sqliteDialect,
+	DialectSQLServer: &sqlServerDialect,
+}
+
+// columnKeywords end the type of a column definition.
+var columnKeywords = map[string]struct{}{
+	"CONSTRAINT": {}, "NOT": {}, "NULL": {}, "DEFAULT": {}, "PRIMARY": {}, "UNIQUE": {},
+	"REFERENCES": {}, "CHECK": {}, "COLLATE": {}, "GENERATED": {}, "AS": {},
+	"AUTOINCREMENT": {}, "AUTO_INCREMENT": {}, "IDENTITY": {}, "COMMENT": {}, "ON": {},
+}
+
+// currentTimeFuncs are default value functions meaning the current time.
+var currentTimeFuncs = map[string]struct{}{
+	"CURRENT_TIMESTAMP": {}, "CURRENT_DATE": {}, "CURRENT_TIME": {}, "LOCALTIMESTAMP": {},
+	"LOCALTIME": {}, "NOW": {}, "GETDATE": {}, "GETUTCDATE": {}, "SYSDATETIME": {},
+	"SYSUTCDATETIME": {}, "DATETIME": {},
+}
+
+// translateDialect translates sql written in dialect d to MySQL, warnings are
+// about what is dropped or guessed.
+func translateDialect(sql string, d Dialect) (string, map[string]tableHints, []Warning, error) {
+	if d == DialectMySQL {
+		return selectMysqlStatements(sql)
+	}
+	spec, ok := dialects[d]
+	if !ok {
+		return sql, nil, nil, nil
+	}
+	if spec.preprocess != nil {
+		sql = spec.preprocess(sql)
+	}
+	tokens, err := lex(sql, spec.lexer)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	t := ddlTranslator{
+		spec:    spec,
+		sql:     sql,
+		out:     make([]token, 0, len(tokens)),
+		hints:   make(map[string]tableHints),
+		columns: make(map[string]map[string][]token),
+	}
+	for _, stmt := range splitStatements(tokens) {
+		if err := t.statement(stmt); err != nil {
+			return "", nil, nil, err
+		}
+	}
+	return renderMysql(t.out), t.hints, t.warnings, nil
+}
+
+type ddlTranslator struct {
+	spec     *dialectSpec
+	sql      string
+	out      []token
+	hints    map[string]tableHints
+	columns  map[string]map[string][]token // translated definitions by lower case table and column
+	table    string                        // name of the table being translated
+	warnings []Warning
+}
+
+// ddlColumn is a column definition being translated.
+type ddlColumn struct {
+	Name          token
+	Type          declaredType
+	PrimaryKey    bool
+	AutoIncrement bool
+	options       []token
+}
+
+func (t *ddlTranslator) statement(stmt []token) error {
+	i := 0
+	if isAlterTable(stmt) {
+		return t.alter(stmt)
+	}
+	if !stmt[i].is("CREATE") {
+		return nil
+	}
+	if isCreateIndex(stmt) {
+		return t.index(stmt)
+	}
+	for i++; i < len(stmt) && stmt[i].is("TEMP", "TEMPORARY", "UNLOGGED", "GLOBAL", "LOCAL"); i++ {
+	}
+	if i >= len(stmt) || !stmt[i].is("TABLE") {
+		return nil
+	}
+	line := stmt[0].line
+	out := []token{wordAt("CREATE", line), wordAt("TABLE", line)}
+	i++
+	if i+2 < len(stmt) && stmt[i].is("IF") && stmt[i+1].is("NOT") && stmt[i+2].is("EXISTS") {
+		out = append(out, stmt[i:i+3]...)
+		i += 3
+	}
+
+	// keep schema and table name of a qualified name
+	names := make([]token, 0, 2)
+	for i < len(stmt) && stmt[i].isName() {
+		names = append(names, quoted(stmt[i]))
+		i++
+		if i >= len(stmt) || !stmt[i].isSymbol(".") {
+			break
+		}
+		i++
+	}
+	if len(names) == 0 {
+		return parseErrorf(line, "missing table name")
+	}
+	if len(names) > 2 {
+		names = names[len(names)-2:]
+	}
+	out = append(out, names[0])
+	if len(names) == 2 {
+		out = append(out, symbolAt(".", line), names[1])
+	}
+	tableName := strings.ToLower(names[len(names)-1].text)
+	t.table = names[len(names)-1].text
+
+	if i >= len(stmt) || !stmt[i].isSymbol("(") {
+		// CREATE TABLE ... AS SELECT has no column definitions
+		return nil
+	}
+	end, ok := groupEnd(stmt, i)
+	if !ok {
+		return parseErrorf(stmt[i].line, "unclosed parenthesis")
+	}
+	hints := tableHints{columns: make(map[string]columnHint)}
+	columns := make(map[string][]token)
+	out = append(out, stmt[i])
+	first := true
+	for _, elem := range splitList(stmt[i+1 : end-1]) {
+		var def []token
+		var err error
+		if isTableConstraint(elem) || t.spec.tableIndexes && elem[0].is("INDEX") {
+			def = t.tableConstraint(elem, &hints)
+		} else {
+			def, err = t.column(elem, &hints)
+			if err != nil {
+				return err
+			}
+			columns[strings.ToLower(elem[0].text)] = def
+		}
+		if len(def) == 0 {
+			continue
+		}
+		if !first {
+			out = append(out, symbolAt(",", out[len(out)-1].line))
+		}
+		first = false
+		out = append(out, def...)
+	}
+	out = append(out, stmt[end-1], symbolAt(";", stmt[end-1].line))
+
+	t.out = append(t.out, out...)
+	t.hints[tableName] = hints
+	t.columns[tableName] = columns
+	return nil
+}
+
+// alter translates ALTER TABLE which adds or drops columns and constraints,
+// like ALTER TABLE ONLY public.users ADD CONSTRAINT users_pkey PRIMARY KEY (id)
+// of pg_dump. A default of sequence makes the column auto increment, other
+// changes are dropped.
+func (t *ddlTranslator) alter(stmt []token) error {
+	line := stmt[0].line
+	i := 2
+	for ; i < len(stmt) && stmt[i].is("IF", "EXISTS", "ONLY"); i++ {
+	}
+	var name token
+	for i < len(stmt) && stmt[i].isName() {
+		name = quoted(stmt[i])
+		if i++; i >= len(stmt) || !stmt[i].isSymbol(".") {
+			break
+		}
+		i++
+	}
+	if name.text == "" {
+		return parseErrorf(line, "missing table name")
+	}
+	for i < len(stmt) && stmt[i].is("WITH", "CHECK", "NOCHECK") {
+		// WITH CHECK ADD CONSTRAINT of SQL Server
+		i++
+	}
+	tableName := strings.ToLower(name.text)
+	t.table = name.text
+	hints := t.hints[tableName]
+	if hints.columns == nil {
+		hints.columns = make(map[string]columnHint)
+	}
+	columns := t.columns[tableName]
+	if columns == nil {
+		columns = make(map[string][]token)
+	}
+
+	out := []token{wordAt("ALTER", line), wordAt("TABLE", line), name}
+	specs := 0
+	action := ""
+	for _, elem := range splitList(stmt[i:]) {
+		if len(elem) == 0 {
+			continue
+		}
+		switch {
+		case elem[0].is("ADD", "DROP"):
+			action = strings.ToUpper(elem[0].text)
+			elem = elem[1:]
+		case elem[0].is("ALTER") && len(elem) > 1:
+			action = ""
+			t.alterColumn(elem[1:], columns, &out, &specs)
+			continue
+		case action == "" || elem[0].kind == tokenWord && isAlterAction(elem[0]):
+			// OWNER TO, RENAME, SET and others
+			action = ""
+			continue
+		}
+		// columns after ADD or DROP of SQL Server are separated by commas
+		var spec []token
+		switch {
+		case len(elem) == 0:
+		case action == "ADD" && (isTableConstraint(elem) || t.spec.tableIndexes && elem[0].is("INDEX")):
+			if def := t.tableConstraint(elem, &hints); len(def) > 0 {
+				spec = append([]token{wordAt("ADD", def[0].line)}, def...)
+			}
+		case action == "ADD":
+			for len(elem) > 0 && elem[0].is("COLUMN", "IF", "NOT", "EXISTS") {
+				elem = elem[1:]
+			}
+			if len(elem) == 0 {
+				break
+			}
+			def, err := t.column(elem, &hints)
+			if err != nil {
+				return err
+			}
+			columns[strings.ToLower(elem[0].text)] = def
+			spec = append([]token{wordAt("ADD", def[0].line), wordAt("COLUMN", def[0].line)}, def...)
+		case action == "DROP" && elem[0].is("CONSTRAINT"):
+			// a unique constraint is an index
+			for elem = elem[1:]; len(elem) > 0 && elem[0].is("IF", "EXISTS"); elem = elem[1:] {
+			}
+			if len(elem) > 0 && elem[0].isName() {
+				spec = []token{wordAt("DROP", elem[0].line), wordAt("INDEX", elem[0].line), quoted(elem[0])}
+			}
+		case action == "DROP" && !elem[0].is("INDEX", "PRIMARY", "FOREIGN"):
+			for len(elem) > 0 && elem[0].is("COLUMN", "IF", "EXISTS") {
+				elem = elem[1:]
+			}
+			if len(elem) > 0 && elem[0].isName() {
+				spec = []token{wordAt("DROP", elem[0].line), wordAt("COLUMN", elem[0].line), quoted(elem[0])}
+				delete(columns, strings.ToLower(elem[0].text))
+			}
+		}
+		if len(spec) == 0 {
+			continue
+		}
+		if specs > 0 {
+			out = append(out, symbolAt(",", spec[0].line))
+		}
+		out = append(out, spec...)
+		specs++
+	}
+	t.hints[tableName] = hints
+	t.columns[tableName] = columns
+	if specs == 0 {
+		return nil
+	}
+	out = append(out, symbolAt(";", out[len(out)-1].line))
+	if _, err := parser.New().Parse(renderMysql(out), "", ""); err != nil {
+		t.warnings = append(t.warnings, newWarning(t.table, "", "ALTER TABLE at line %d is skipped, it's not supported", line))
+		return nil
+	}
+	t.out = append(t.out, out...)
+	return nil
+}
+
+// alterColumn translates ALTER COLUMN c SET DEFAULT nextval(...), the column of
+// a sequence is auto increment. It's how pg_dump writes serial columns.
+func (t *ddlTranslator) alterColumn(elem []token, columns map[string][]token, out *[]token, specs *int) {
+	if elem[0].is("COLUMN") {
+		elem = elem[1:]
+	}
+	if len(elem) < 4 || !elem[0].isName() || !elem[1].is("SET") || !elem[2].is("DEFAULT") || !elem[3].is("NEXTVAL") {
+		return
+	}
+	def, ok := columns[strings.ToLower(elem[0].text)]
+	if !ok {
+		return
+	}
+	for _, tk := range def {
+		if tk.is("AUTO_INCREMENT") {
+			return
+		}
+	}
+	line := elem[0].line
+	def = append(def[:len(def):len(def)], wordAt("AUTO_INCREMENT", line))
+	columns[strings.ToLower(elem[0].text)] = def
+	if *specs > 0 {
+		*out = append(*out, symbolAt(",", line))
+	}
+	*out = append(*out, wordAt("MODIFY", line), wordAt("COLUMN", line))
+	*out = append(*out, def...)
+	*specs++
+}
+
+// isAlterAction reports whether a word starts a change of ALTER TABLE other
+// than ADD and DROP, which ends the columns after ADD or DROP.
+func isAlterAction(tk token) bool {
+	return tk.is("ALTER", "RENAME", "OWNER", "SET", "RESET", "ENABLE", "DISABLE", "VALIDATE",
+		"INHERIT", "NO", "CLUSTER", "REPLICA", "FORCE", "ATTACH", "DETACH", "OF", "NOT", "CHECK", "NOCHECK")
+}
+
+// index translates CREATE INDEX of columns, indexes of expressions are dropped.
+func (t *ddlTranslator) index(stmt []token) error {
+	line := stmt[0].line
+	out := []token{wordAt("CREATE", line)}
+	i := 1
+	if stmt[i].is("UNIQUE") {
+		out = append(out, stmt[i])
+		i++
+	}
+	for ; i < len(stmt) && stmt[i].is("CLUSTERED", "NONCLUSTERED"); i++ {
+	}
+	out = append(out, wordAt("INDEX", line))
+	for i++; i < len(stmt) && stmt[i].is("CONCURRENTLY", "IF", "NOT", "EXISTS"); i++ {
+	}
+	if i >= len(stmt) || !stmt[i].isName() || stmt[i].is("ON") {
+		// the name of index is optional in postgres
+		return nil
+	}
+	out = append(out, quoted(stmt[i]))
+	if i++; i >= len(stmt) || !stmt[i].is("ON") {
+		return nil
+	}
+	for i++; i < len(stmt) && stmt[i].is("ONLY"); i++ {
+	}
+	out = append(out, wordAt("ON", line))
+	if i+2 < len(stmt) && stmt[i].isName() && stmt[i+1].isSymbol(".") {
+		// the schema is dropped like the table name of CREATE TABLE
+		i += 2
+	}
+	if i >= len(stmt) || !stmt[i].isName() {
+		return nil
+	}
+	out = append(out, quoted(stmt[i]))
+	for i < len(stmt) && !stmt[i].isSymbol("(") {
+		i++
+	}
+	end, ok := groupEnd(stmt, i)
+	if i >= len(stmt) {
+		return nil
+	}
+	if !ok {
+		return parseErrorf(stmt[i].line, "unclosed parenthesis")
+	}
+	cols := stmt[i:end]
+	out = append(out, cols[0])
+	for n, col := range splitList(cols[1 : len(cols)-1]) {
+		if len(col) == 0 || !col[0].isName() {
+			return nil
+		}
+		if n > 0 {
+			out = append(out, symbolAt(",", col[0].line))
+		}
+		out = append(out, quoted(col[0]))
+		for _, tk := range col[1:] {
+			switch {
+			case tk.is("ASC", "DESC"):
+				out = append(out, tk)
+			case tk.is("NULLS", "FIRST", "LAST"):
+			default:
+				return nil
+			}
+		}
+	}
+	out = append(out, cols[len(cols)-1])
+	t.out = append(t.out, append(out, symbolAt(";", out[len(out)-1].line))...)
+	return nil
+}
+
+func (t *ddlTranslator) column(elem []token, hints *tableHints) ([]token, error) {
+	if !elem[0].isName() {
+		return nil, parseErrorf(elem[0].line, "unexpected %q in column definition", elem[0].text)
+	}
+	col := ddlColumn{Name: elem[0]}
+	var i int
+	col.Type, i = readType(elem, 1)
+	tp := t.spec.mapType(col.Type)
+	if tp.Unknown {
+		t.warnings = append(t.warnings, newWarning(t.table, col.Name.text, "unknown type %s is mapped to text", col.Type))
+	}
+	col.AutoIncrement = tp.AutoIncrement
+	line := elem[0].line
+
+	constraint := ""
+	for i < len(elem) {
+		tk := elem[i]
+		switch {
+		case tk.is("CONSTRAINT"):
+			if i+1 < len(elem) {
+				constraint = elem[i+1].text
+			}
+			i += 2
+		case tk.is("NOT") && i+1 < len(elem) && elem[i+1].is("NULL"):
+			col.options = append(col.options, elem[i:i+2]...)
+			i += 2
+		case tk.is("NULL"):
+			col.options = append(col.options, tk)
+			i++
+		case tk.is("PRIMARY"):
+			col.PrimaryKey = true
+			col.options = append(col.options, tk, wordAt("KEY", tk.line))
+			for i += 2; i < len(elem) && elem[i].is("ASC", "DESC", "CLUSTERED", "NONCLUSTERED"); i++ {
+			}
+		case tk.is("UNIQUE"):
+			col.options = append(col.options, tk)
+			for i++; i < len(elem) && elem[i].is("CLUSTERED", "NONCLUSTERED"); i++ {
+			}
+		case tk.is("DEFAULT"):
+			var expr []token
+			expr, i = readExpr(elem, i+1)
+			if len(expr) > 0 && expr[0].is("NEXTVAL") {
+				// a default value from a sequence means auto increment
+				col.AutoIncrement = true
+			} else if value, ok := defaultValue(expr); ok {
+				col.options = append(col.options, tk, value)
+			}
+		case tk.is("REFERENCES"):
+			var ref []token
+			ref, i = readReference(elem, i)
+			col.options = append(col.options, ref...)
+		case tk.is("CHECK"):
+			var check CheckInfo
+			check, i = readCheck(elem, i, t.sql)
+			check.Name = constraint
+			if check.Expr != "" {
+				hints.checks = append(hints.checks, check)
+			}
+		case tk.is("COLLATE"):
+			i += 2
+		case tk.is("GENERATED") && i+4 < len(elem) && elem[i+1].is("BY") && elem[i+3].is("AS") && elem[i+4].is("IDENTITY"):
+			// GENERATED BY DEFAULT AS IDENTITY
+			col.AutoIncrement = true
+			i = skipIdentity(elem, i+5)
+		case tk.is("GENERATED") && i+1 < len(elem) && elem[i+1].is("BY"):
+			// not an identity without AS IDENTITY
+			for i += 2; i < len(elem) && elem[i].is("DEFAULT", "ALWAYS"); i++ {
+			}
+		case tk.is("GENERATED") && i+3 < len(elem) && elem[i+3].is("IDENTITY"):
+			col.AutoIncrement = true
+			i = skipIdentity(elem, i+4)
+		case tk.is("GENERATED", "AS"):
+			for i < len(elem) && !elem[i].isSymbol("(") {
+				i++
+			}
+			if i < len(elem) {
+				end := skipGroup(elem, i)
+				col.options = append(col.options, wordAt("AS", tk.line))
+				col.options = append(col.options, elem[i:end]...)
+				i = end
+				if i < len(elem) && elem[i].is("STORED", "VIRTUAL") {
+					col.options = append(col.options, elem[i])
+					i++
+				}
+			}
+		case tk.is("AUTOINCREMENT", "AUTO_INCREMENT", "IDENTITY"):
+			col.AutoIncrement = true
+			i = skipIdentity(elem, i+1)
+		case tk.is("COMMENT") && i+1 < len(elem) && elem[i+1].kind == tokenString:
+			col.options = append(col.options, elem[i:i+2]...)
+			i += 2
+		case tk.is("ON") && i+1 < len(elem) && elem[i+1].is("CONFLICT"):
+			i += 3
+		default:
+			i++
+		}
+	}
+	if t.spec.fixColumn != nil {
+		t.spec.fixColumn(&col)
+	}
+
+	hints.columns[strings.ToLower(col.Name.text)] = columnHint{
+		RawType:   col.Type.String(),
+		ArrayDims: col.Type.ArrayDims,
+		GoType:    tp.GoType,
+		Unknown:   tp.Unknown,
+	}
+	def := []token{quoted(col.Name), wordAt(tp.Mysql, line)}
+	def = append(def, col.options...)
+	if col.AutoIncrement {
+		def = append(def, wordAt("AUTO_INCREMENT", line))
+	}
+	return def, nil
+}
+
+// isTableConstraint reports whether a table element is a constraint. These
+// words are reserved, a column with such name must be quoted.
+func isTableConstraint(elem []token) bool {
+	return elem[0].is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "EXCLUDE")
+}
+
+func (t *ddlTranslator) tableConstraint(elem []token, hints *tableHints) []token {
+	i := 0
+	var name token
+	if elem[i].is("CONSTRAINT") {
+		if len(elem) < 2 {
+			return nil
+		}
+		name = quoted(elem[1])
+		i = 2
+	}
+	if i >= len(elem) {
+		return nil
+	}
+	tk := elem[i]
+	line := tk.line
+	out := make([]token, 0, len(elem))
+	switch {
+	case tk.is("PRIMARY"):
+		cols := findGroup(elem, i)
+		if cols == nil {
+			return nil
+		}
+		out = append(out, tk, wordAt("KEY", line))
+		out = append(out, quoteNames(cols)...)
+	case tk.is("UNIQUE"):
+		cols := findGroup(elem, i)
+		if cols == nil {
+			return nil
+		}
+		out = append(out, tk, wordAt("KEY", line))
+		if name.text != "" {
+			out = append(out, name)
+		}
+		out = append(out, quoteNames(cols)...)
+	case tk.is("KEY", "INDEX"):
+		cols := findGroup(elem, i)
+		if cols == nil {
+			return nil
+		}
+		out = append(out, tk)
+		if i+1 < len(elem) && elem[i+1].isName() {
+			out = append(out, quoted(elem[i+1]))
+		}
+		out = append(out, quoteNames(cols)...)
+	case tk.is("FOREIGN"):
+		cols := findGroup(elem, i)
+		if cols == nil {
+			return nil
+		}
+		if name.text != "" {
+			out = append(out, wordAt("CONSTRAINT", line), name)
+		}
+		out = append(out, tk, wordAt("KEY", line))
+		out = append(out, quoteNames(cols)...)
+		for i < len(elem) && !elem[i].is("REFERENCES") {
+			i++
+		}
+		if i < len(elem) {
+			ref, _ := readReference(elem, i)
+			out = append(out, ref...)
+		}
+	case tk.is("CHECK"):
+		check, _ := readCheck(elem, i, t.sql)
+		check.Name = name.text
+		if check.Expr != "" {
+			hints.checks = append(hints.checks, check)
+		}
+		return nil
+	default:
+		// EXCLUDE and other constraints are not needed to generate code
+		t.warnings = append(t.warnings, newWarning(t.table, "", "%s constraint is dropped", strings.ToUpper(tk.text)))
+		return nil
+	}
+	return out
+}
+
+// readType reads the declared type starting at i, it may be empty for
+// dialects allowing columns without types.
+func readType(elem []token, i int) (declaredType, int) {
+	tp := declaredType{}
+	words := make([]string, 0, 2)
+	for i < len(elem) {
+		tk := elem[i]
+		switch {
+		case tk.kind == tokenWord && tk.is("ARRAY"):
+			tp.ArrayDims++
+			i++
+			if i < len(elem) && elem[i].isSymbol("[") {
+				i = skipBracket(elem, i)
+			}
+		case tk.kind == tokenQuoted && len(words) == 0:
+			// quoted type name like [int] of SQL Server
+			words = append(words, strings.ToLower(tk.text))
+			i++
+		case tk.kind == tokenWord:
+			if _, ok := columnKeywords[strings.ToUpper(tk.text)]; ok {
+				goto done
+			}
+			words = append(words, strings.ToLower(tk.text))
+			i++
+		case tk.isSymbol("(") && len(words) > 0 && tp.Args == nil:
+			end := skipGroup(elem, i)
+			for _, arg := range splitList(elem[i+1 : end-1]) {
+				s := make([]string, 0, len(arg))
+				for _, a := range arg {
+					s = append(s, strings.ToLower(a.text))
+				}
+				tp.Args = append(tp.Args, strings.Join(s, " "))
+			}
+			i = end
+		case tk.isSymbol("[") && len(words) > 0:
+			tp.ArrayDims++
+			i = skipBracket(elem, i)
+		default:
+			goto done
+		}
+	}
+done:
+	tp.Name = strings.Join(words, " ")
+	return tp, i
+}
+
+// readExpr reads a simple expression starting at i: operands joined by
+// operators, with function calls, parenthesis and casts.
+func readExpr(elem []token, i int) ([]token, int) {
+	start := i
+	for i < len(elem) {
+		for i < len(elem) && (elem[i].isSymbol("-") || elem[i].isSymbol("+")) {
+			i++
+		}
+		if i >= len(elem) {
+			break
+		}
+		if elem[i].isSymbol("(") {
+			i = skipGroup(elem, i)
+		} else if elem[i].kind != tokenSymbol {
+			i++
+			if i < len(elem) && elem[i].isSymbol("(") {
+				i = skipGroup(elem, i)
+			}
+		} else {
+			break
+		}
+		for i < len(elem) && elem[i].isSymbol("::") {
+			_, i = readType(elem, i+1)
+		}
+		if i < len(elem) && isOperator(elem[i]) {
+			i++
+			continue
+		}
+		break
+	}
+	return elem[start:i], i
+}
+
+// defaultValue translates a default value expression, ok is false if it's
+// not a value MySQL can take.
+func defaultValue(expr []token) (value token, ok bool) {
+	// remove casts and redundant parenthesis: ('now'::text)::date, ((0))
+	for {
+		if n := len(expr); n >= 2 && expr[0].isSymbol("(") && skipGroup(expr, 0) == n {
+			expr = expr[1 : n-1]
+			continue
+		}
+		if c := indexSymbol(expr, "::"); c > 0 {
+			expr = expr[:c]
+			continue
+		}
+		break
+	}
+	if len(expr) == 0 {
+		return
+	}
+	tk := expr[0]
+	switch {
+	case len(expr) == 1 && (tk.kind == tokenString || tk.kind == tokenNumber):
+		return tk, true
+	case len(expr) == 1 && tk.is("NULL", "TRUE", "FALSE"):
+		return tk, true
+	case len(expr) == 2 && tk.isSymbol("-") && expr[1].kind == tokenNumber:
+		return token{kind: tokenNumber, text: "-" + expr[1].text, line: tk.line}, true
+	case tk.kind == tokenWord:
+		if _, isTime := currentTimeFuncs[strings.ToUpper(tk.text)]; isTime {
+			return wordAt("CURRENT_TIMESTAMP", tk.line), true
+		}
+	}
+	return
+}
+
+// readReference reads "REFERENCES table (columns) [ON DELETE action] ..."
+// starting at i and keeps the parts MySQL supports.
+func readReference(elem []token, i int) ([]token, int) {
+	out := []token{elem[i]}
+	i++
+	// only the table name of a qualified name is kept
+	for i < len(elem) && elem[i].isName() {
+		name := quoted(elem[i])
+		i++
+		if i < len(elem) && elem[i].isSymbol(".") {
+			i++
+			continue
+		}
+		out = append(out, name)
+		break
+	}
+	if i < len(elem) && elem[i].isSymbol("(") {
+		end := skipGroup(elem, i)
+		out = append(out, quoteNames(elem[i:end])...)
+		i = end
+	}
+	for i < len(elem) {
+		switch {
+		case elem[i].is("ON") && i+1 < len(elem) && elem[i+1].is("DELETE", "UPDATE"):
+			j := i + 2
+			if j+1 < len(elem) && (elem[j].is("NO") && elem[j+1].is("ACTION") ||
+				elem[j].is("SET") && elem[j+1].is("NULL", "DEFAULT")) {
+				j += 2
+			} else if j < len(elem) && elem[j].is("CASCADE", "RESTRICT") {
+				j++
+			} else {
+				return out, j
+			}
+			out = append(out, elem[i:j]...)
+			i = j
+		case elem[i].is("MATCH", "INITIALLY"):
+			i += 2
+		case elem[i].is("DEFERRABLE"):
+			i++
+		case elem[i].is("NOT") && i+1 < len(elem) && elem[i+1].is("DEFERRABLE", "FOR"):
+			// NOT DEFERRABLE, NOT FOR REPLICATION
+			i += 2
+			if i < len(elem) && elem[i].is("REPLICATION") {
+				i++
+			}
+		default:
+			return out, i
+		}
+	}
+	return out, i
+}
+
+// skipIdentity skips the optional arguments of an identity: IDENTITY(1,1) or
+// GENERATED AS IDENTITY (START WITH 1).
+func skipIdentity(elem []token, i int) int {
+	if i < len(elem) && elem[i].isSymbol("(") {
+		return skipGroup(elem, i)
+	}
+	return i
+}
+
+// skipGroup returns the index after the parenthesis group starting at i.
+func skipGroup(tokens []token, i int) int {
+	end, _ := groupEnd(tokens, i)
+	return end
+}
+
+// groupEnd is skipGroup reporting whether the group is closed, it's the end
+// of tokens for an unclosed group.
+func groupEnd(tokens []token, i int) (int, bool) {
+	if i >= len(tokens) || !tokens[i].isSymbol("(") {
+		return i, false
+	}
+	depth := 0
+	for ; i < len(tokens); i++ {
+		if tokens[i].isSymbol("(") {
+			depth++
+		} else if tokens[i].isSymbol(")") {
+			depth--
+			if depth == 0 {
+				return i + 1, true
+			}
+		}
+	}
+	return i, false
+}
+
+func skipBracket(tokens []token, i int) int {
+	for ; i < len(tokens); i++ {
+		if tokens[i].isSymbol("]") {
+			return i + 1
+		}
+	}
+	return i
+}
+
+// findGroup returns the first parenthesis group at or after i, including the
+// parenthesis.
+func findGroup(tokens []token, i int) []token {
+	for ; i < len(tokens); i++ {
+		if tokens[i].isSymbol("(") {
+			return tokens[i:skipGroup(tokens, i)]
+		}
+	}
+	return nil
+}
+
+// splitList splits tokens by commas outside parenthesis.
+func splitList(tokens []token) [][]token {
+	list := make([][]token, 0, 4)
+	depth := 0
+	start := 0
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case t.isSymbol(",") && depth == 0:
+			if i > start {
+				list = append(list, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		list = append(list, tokens[start:])
+	}
+	return list
+}
+
+func isOperator(t token) bool {
+	if t.kind != tokenSymbol {
+		return t.is("AND", "OR")
+	}
+	switch t.text {
+	case "+", "-", "*", "/", "%", "||", "=", "<", ">", "<=", ">=", "<>", "!=":
+		return true
+	}
+	return false
+}
+
+func indexSymbol(tokens []token, s string) int {
+	for i, t := range tokens {
+		if t.isSymbol(s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// quoteNames quotes every identifier in a column list like (a, b DESC).
+func quoteNames(tokens []token) []token {
+	out := make([]token, len(tokens))
+	for i, t := range tokens {
+		if t.kind == tokenWord && !t.is("ASC", "DESC") {
+			t = quoted(t)
+		}
+		out[i] = t
+	}
+	return out
+}
+
+func quoted(t token) token {
+	t.kind = tokenQuoted
+	return t
+}
+
+func wordAt(s string, line int) token {
+	return token{kind: tokenWord, text: s, line: line}
+}
+
+func symbolAt(s string, line int) token {
+	return token{kind: tokenSymbol, text: s, line: line}
+}
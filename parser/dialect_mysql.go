@@ -0,0 +1,386 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/parser"
+)
+
+var mysqlLexer = lexerConfig{
+	identQuotes:     "`",
+	backslashEscape: true,
+	hashComment:     true,
+	doubleQuoteStr:  true,
+}
+
+// selectMysqlStatements keeps CREATE TABLE, CREATE INDEX, ALTER TABLE and CREATE VIEW statements
+// of a MySQL script, such as a mysqldump output, other statements are dropped
+// since the parser may not support them. Statements are kept on their lines of
+// source. CHECK constraints are blanked and returned in hints since the parser
+// doesn't support them, an ALTER TABLE or CREATE VIEW the parser can't parse is
+// dropped.
+func selectMysqlStatements(sql string) (string, map[string]tableHints, []Warning, error) {
+	sql = replaceDelimiters(sql)
+	tokens, err := lex(sql, mysqlLexer)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	hints := make(map[string]tableHints)
+	var warnings []Warning
+	b := strings.Builder{}
+	line := 1
+	for _, stmt := range splitStatements(tokens) {
+		view := isCreateView(stmt)
+		if !isCreateTable(stmt) && !isCreateIndex(stmt) && !isAlterTable(stmt) && !view {
+			continue
+		}
+		text := sql[stmt[0].pos:stmt[len(stmt)-1].end]
+		if view {
+			text = sql[stmt[0].pos:stmt[trimCheckOption(stmt)-1].end]
+		}
+		if isAlterTable(stmt) || isCreateIndex(stmt) {
+			text = blankSpatialIndex(text, stmt[0].pos, stmt)
+		}
+		if isAlterTable(stmt) || view {
+			if _, err := parser.New().Parse(text, "", ""); err != nil {
+				kind := "ALTER TABLE"
+				if view {
+					kind = "CREATE VIEW"
+				}
+				warnings = append(warnings, newWarning("", "", "%s at line %d is skipped, it's not supported: %s", kind, stmt[0].line, text))
+				continue
+			}
+		}
+		if n := stmt[0].line - line; n > 0 {
+			b.WriteString(strings.Repeat("\n", n))
+		} else if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		if isCreateTable(stmt) {
+			var checks []CheckInfo
+			var columns map[string]columnHint
+			name, body := mysqlTableBody(stmt)
+			checks, text = removeMysqlChecks(sql, stmt, body)
+			columns, text = replaceSpatialTypes(text, stmt[0].pos, body)
+			columns, text = replaceDefaultExprs(text, stmt[0].pos, body, columns)
+			columns = boolTypeHints(body, columns)
+			columns = timeFspHints(body, columns)
+			if len(checks) > 0 || len(columns) > 0 {
+				hints[name] = tableHints{checks: checks, columns: columns}
+			}
+		}
+		if stmt[1].is("TEMPORARY") {
+			// the parser doesn't support temporary tables
+			text = "CREATE " + text[stmt[2].pos-stmt[0].pos:]
+		}
+		b.WriteString(text)
+		b.WriteByte(';')
+		line = stmt[len(stmt)-1].line
+	}
+	return b.String(), hints, warnings, nil
+}
+
+// mysqlTableBody finds the lower case table name and the definitions in
+// parenthesis of CREATE TABLE, the definitions are nil if the parenthesis
+// isn't closed and the parser reports the syntax error.
+func mysqlTableBody(stmt []token) (string, []token) {
+	i := 2
+	if stmt[1].is("TEMPORARY") {
+		i++
+	}
+	if i+2 < len(stmt) && stmt[i].is("IF") && stmt[i+1].is("NOT") && stmt[i+2].is("EXISTS") {
+		i += 3
+	}
+	name := ""
+	for i < len(stmt) && stmt[i].isName() {
+		name = strings.ToLower(stmt[i].text)
+		if i++; i >= len(stmt) || !stmt[i].isSymbol(".") {
+			break
+		}
+		i++
+	}
+	end, ok := groupEnd(stmt, i)
+	if !ok {
+		return name, nil
+	}
+	return name, stmt[i+1 : end-1]
+}
+
+// removeMysqlChecks blanks CHECK constraints in the text of stmt, lines and
+// columns of other tokens are not changed.
+func removeMysqlChecks(sql string, stmt []token, body []token) ([]CheckInfo, string) {
+	start := stmt[0].pos
+	text := []byte(sql[start:stmt[len(stmt)-1].end])
+	blank := func(from, to int) {
+		for i := from - start; i < to-start; i++ {
+			if text[i] != '\n' {
+				text[i] = ' '
+			}
+		}
+	}
+	var checks []CheckInfo
+	elems := splitList(body)
+	for n, elem := range elems {
+		for i := 0; i < len(elem); i++ {
+			if !elem[i].is("CHECK") {
+				continue
+			}
+			from := i
+			check, to := readCheck(elem, i, sql)
+			if i >= 2 && elem[i-2].is("CONSTRAINT") {
+				from = i - 2
+				check.Name = elem[i-1].text
+			}
+			if check.Expr != "" {
+				checks = append(checks, check)
+			}
+			switch {
+			case from > 0:
+				blank(elem[from].pos, elem[to-1].end)
+			case n > 0:
+				// the table constraint and the comma in front of it
+				prev := elems[n-1]
+				blank(prev[len(prev)-1].end, elem[to-1].end)
+			case n+1 < len(elems):
+				blank(elem[0].pos, elems[n+1][0].pos)
+			}
+			i = to - 1
+		}
+	}
+	return checks, string(text)
+}
+
+// mysqlSpatialTypes are spatial types the parser doesn't support
+var mysqlSpatialTypes = []string{
+	"GEOMETRY", "POINT", "LINESTRING", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON",
+	"GEOMETRYCOLLECTION", "GEOMCOLLECTION",
+}
+
+// replaceSpatialTypes replaces spatial types in the text of CREATE TABLE by
+// blob, they are []byte of WKB in hints. SRID of column and SPATIAL of index
+// are blanked. Lines and columns of other tokens are not changed.
+func replaceSpatialTypes(text string, start int, body []token) (map[string]columnHint, string) {
+	b := []byte(text)
+	put := func(tk token, s string) {
+		copy(b[tk.pos-start:tk.end-start], s+strings.Repeat(" ", tk.end-tk.pos-len(s)))
+	}
+	var columns map[string]columnHint
+	for _, elem := range splitList(body) {
+		if len(elem) > 1 && elem[0].is("SPATIAL") && elem[1].is("KEY", "INDEX") {
+			put(elem[0], "")
+			continue
+		}
+		if len(elem) < 2 || !elem[0].isName() || !elem[1].is(mysqlSpatialTypes...) {
+			continue
+		}
+		if columns == nil {
+			columns = make(map[string]columnHint)
+		}
+		columns[strings.ToLower(elem[0].text)] = columnHint{RawType: strings.ToLower(elem[1].text), GoType: "[]byte"}
+		put(elem[1], "blob")
+		for i := 2; i+1 < len(elem); i++ {
+			if elem[i].is("SRID") {
+				put(elem[i], "")
+				put(elem[i+1], "")
+			}
+		}
+	}
+	return columns, string(b)
+}
+
+// mysqlTimeDefaults are functions the parser supports in DEFAULT
+var mysqlTimeDefaults = []string{"CURRENT_TIMESTAMP", "NOW", "LOCALTIME", "LOCALTIMESTAMP"}
+
+// replaceDefaultExprs blanks DEFAULT of a function call like uuid() of
+// MariaDB, an expression in parentheses of MySQL 8 or CURRENT_DATE, which the
+// parser doesn't support. The expression is kept in hints. Lines and columns of other tokens
+// are not changed.
+func replaceDefaultExprs(text string, start int, body []token, columns map[string]columnHint) (map[string]columnHint, string) {
+	b := []byte(text)
+	for _, elem := range splitList(body) {
+		if len(elem) < 2 || !elem[0].isName() || elem[0].kind == tokenWord &&
+			elem[0].is("PRIMARY", "KEY", "INDEX", "UNIQUE", "CONSTRAINT", "FOREIGN", "FULLTEXT", "SPATIAL", "CHECK") {
+			continue
+		}
+		for i := 1; i+1 < len(elem); i++ {
+			if !elem[i].is("DEFAULT") {
+				continue
+			}
+			expr := i + 1
+			if elem[expr].kind == tokenWord && expr+1 < len(elem) && elem[expr+1].isSymbol("(") &&
+				!elem[expr].is(mysqlTimeDefaults...) {
+				expr++
+			}
+			end := expr + 1
+			switch {
+			case elem[expr].isSymbol("("):
+				end = skipGroup(elem, expr)
+			case !elem[expr].is("CURRENT_DATE", "CURRENT_TIME", "CURRENT_USER"):
+				continue
+			}
+			if columns == nil {
+				columns = make(map[string]columnHint)
+			}
+			name := strings.ToLower(elem[0].text)
+			hint := columns[name]
+			hint.Default = text[elem[i+1].pos-start : elem[end-1].end-start]
+			columns[name] = hint
+			for j := elem[i].pos - start; j < elem[end-1].end-start; j++ {
+				if b[j] != '\n' {
+					b[j] = ' '
+				}
+			}
+			break
+		}
+	}
+	return columns, string(b)
+}
+
+// blankSpatialIndex blanks SPATIAL of CREATE SPATIAL INDEX and ADD SPATIAL
+// INDEX, the parser doesn't support it. An index of a spatial column is
+// SPATIAL anyway.
+func blankSpatialIndex(text string, start int, stmt []token) string {
+	b := []byte(text)
+	for i, tk := range stmt[:len(stmt)-1] {
+		if tk.kind == tokenWord && tk.is("SPATIAL") && stmt[i+1].is("KEY", "INDEX") {
+			copy(b[tk.pos-start:tk.end-start], strings.Repeat(" ", tk.end-tk.pos))
+		}
+	}
+	return string(b)
+}
+
+// boolTypeHints keeps bool and boolean columns as bool in hints, the parser
+// makes them tinyint(1) which can't be told from a declared tinyint(1).
+func boolTypeHints(body []token, columns map[string]columnHint) map[string]columnHint {
+	for _, elem := range splitList(body) {
+		if len(elem) < 2 || !elem[0].isName() || elem[1].kind != tokenWord || !elem[1].is("BOOL", "BOOLEAN") {
+			continue
+		}
+		if columns == nil {
+			columns = make(map[string]columnHint)
+		}
+		name := strings.ToLower(elem[0].text)
+		hint := columns[name]
+		hint.RawType = strings.ToLower(elem[1].text)
+		hint.GoType = "bool"
+		columns[name] = hint
+	}
+	return columns
+}
+
+// timeFspHints keeps fsp of the current time in DEFAULT and ON UPDATE in
+// hints, e.g. 3 of CURRENT_TIMESTAMP(3), the parser drops it.
+func timeFspHints(body []token, columns map[string]columnHint) map[string]columnHint {
+	for _, elem := range splitList(body) {
+		if len(elem) < 2 || !elem[0].isName() {
+			continue
+		}
+		for i := 1; i+4 < len(elem); i++ {
+			onUpdate := elem[i].is("UPDATE") && elem[i-1].is("ON")
+			if !elem[i].is("DEFAULT") && !onUpdate || !elem[i+1].is(mysqlTimeDefaults...) ||
+				!elem[i+2].isSymbol("(") || elem[i+3].kind != tokenNumber || !elem[i+4].isSymbol(")") {
+				continue
+			}
+			if columns == nil {
+				columns = make(map[string]columnHint)
+			}
+			name := strings.ToLower(elem[0].text)
+			hint := columns[name]
+			if onUpdate {
+				hint.OnUpdateFsp = elem[i+3].text
+			} else {
+				hint.DefaultFsp = elem[i+3].text
+			}
+			columns[name] = hint
+		}
+	}
+	return columns
+}
+
+func isCreateTable(stmt []token) bool {
+	if !stmt[0].is("CREATE") {
+		return false
+	}
+	i := 1
+	if i < len(stmt) && stmt[i].is("TEMPORARY") {
+		i++
+	}
+	return i < len(stmt) && stmt[i].is("TABLE")
+}
+
+func isAlterTable(stmt []token) bool {
+	return len(stmt) > 1 && stmt[0].is("ALTER") && stmt[1].is("TABLE")
+}
+
+// isCreateView reports whether stmt is CREATE VIEW, options like OR REPLACE,
+// ALGORITHM and DEFINER may be in front of VIEW.
+func isCreateView(stmt []token) bool {
+	if !stmt[0].is("CREATE") {
+		return false
+	}
+	for _, t := range stmt[1:] {
+		if t.is("VIEW") {
+			return true
+		}
+		if t.is("AS", "TABLE", "INDEX") || t.isSymbol("(") {
+			return false
+		}
+	}
+	return false
+}
+
+// trimCheckOption returns the end of CREATE VIEW without WITH [CASCADED |
+// LOCAL] CHECK OPTION which the parser doesn't support.
+func trimCheckOption(stmt []token) int {
+	n := len(stmt)
+	if n < 4 || !stmt[n-2].is("CHECK") || !stmt[n-1].is("OPTION") {
+		return n
+	}
+	if stmt[n-3].is("WITH") {
+		return n - 3
+	}
+	if n > 4 && stmt[n-3].is("CASCADED", "LOCAL") && stmt[n-4].is("WITH") {
+		return n - 4
+	}
+	return n
+}
+
+// isCreateIndex reports whether stmt is CREATE [UNIQUE] INDEX, CLUSTERED and
+// NONCLUSTERED of SQL Server are allowed as well.
+func isCreateIndex(stmt []token) bool {
+	if len(stmt) < 2 || !stmt[0].is("CREATE") {
+		return false
+	}
+	i := 1
+	if stmt[i].is("UNIQUE", "SPATIAL") {
+		i++
+	}
+	for ; i < len(stmt) && stmt[i].is("CLUSTERED", "NONCLUSTERED"); i++ {
+	}
+	return i < len(stmt) && stmt[i].is("INDEX")
+}
+
+// replaceDelimiters replaces custom delimiters set by the DELIMITER command of
+// mysql client with semicolons, DELIMITER lines are blanked.
+func replaceDelimiters(sql string) string {
+	if !strings.Contains(strings.ToUpper(sql), "DELIMITER") {
+		return sql
+	}
+	lines := strings.Split(sql, "\n")
+	delimiter := ";"
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.EqualFold(fields[0], "DELIMITER") {
+			delimiter = fields[1]
+			lines[i] = ""
+			continue
+		}
+		if delimiter != ";" {
+			trimmed := strings.TrimRight(line, " \t\r")
+			if strings.HasSuffix(trimmed, delimiter) {
+				lines[i] = trimmed[:len(trimmed)-len(delimiter)] + ";"
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/types"
+)
+
+var postgresDialect = dialectSpec{
+	lexer: lexerConfig{
+		identQuotes:   `"`,
+		dollarQuote:   true,
+		escapeStrings: true,
+	},
+	preprocess: dropCopyData,
+	mapType:    postgresType,
+}
+
+// postgresTypes maps postgres types to MySQL types, arguments of the declared
+// type are kept for types in postgresTypesWithArgs.
+var postgresTypes = map[string]string{
+	"smallint":                    "smallint",
+	"int2":                        "smallint",
+	"integer":                     "int",
+	"int":                         "int",
+	"int4":                        "int",
+	"bigint":                      "bigint",
+	"int8":                        "bigint",
+	"smallserial":                 "smallint",
+	"serial2":                     "smallint",
+	"serial":                      "int",
+	"serial4":                     "int",
+	"bigserial":                   "bigint",
+	"serial8":                     "bigint",
+	"real":                        "float",
+	"float4":                      "float",
+	"double precision":            "double",
+	"float8":                      "double",
+	"float":                       "double",
+	"numeric":                     "decimal",
+	"decimal":                     "decimal",
+	"money":                       "decimal(19,2)",
+	"boolean":                     "boolean",
+	"bool":                        "boolean",
+	"character varying":           "varchar",
+	"varchar":                     "varchar",
+	"character":                   "char",
+	"char":                        "char",
+	"bpchar":                      "char",
+	"text":                        "text",
+	"citext":                      "text",
+	"name":                        "varchar(63)",
+	"bytea":                       "blob",
+	"date":                        "date",
+	"time":                        "time",
+	"timetz":                      "time",
+	"time with time zone":         "time",
+	"time without time zone":      "time",
+	"timestamp":                   "timestamp",
+	"timestamptz":                 "timestamp",
+	"timestamp with time zone":    "timestamp",
+	"timestamp without time zone": "timestamp",
+	"interval":                    "varchar(64)",
+	"json":                        "json",
+	"jsonb":                       "json",
+	"uuid":                        "char(36)",
+	"inet":                        "varchar(43)",
+	"cidr":                        "varchar(43)",
+	"macaddr":                     "varchar(17)",
+	"xml":                         "text",
+	"tsvector":                    "text",
+	"geometry":                    "blob", // PostGIS, the value is WKB
+	"geography":                   "blob",
+}
+
+var postgresTypesWithArgs = map[string]struct{}{
+	"varchar": {}, "char": {}, "decimal": {}, "time": {}, "timestamp": {},
+}
+
+// postgresGoTypes are postgres types which are better not mapped like their
+// MySQL counterpart.
+var postgresGoTypes = map[string]string{
+	"boolean":   "bool",
+	"bool":      "bool",
+	"jsonb":     "[]byte",
+	"bytea":     "[]byte",
+	"geometry":  "[]byte",
+	"geography": "[]byte",
+}
+
+func postgresType(t declaredType) translatedType {
+	mysqlType, ok := postgresTypes[t.Name]
+	if !ok {
+		// enum, domain and other user defined types
+		mysqlType = "text"
+	}
+	if _, keepArgs := postgresTypesWithArgs[mysqlType]; keepArgs && len(t.Args) > 0 {
+		mysqlType += "(" + strings.Join(t.Args, ",") + ")"
+	} else if mysqlType == "varchar" {
+		// varchar without length is unlimited
+		mysqlType = "text"
+	} else if mysqlType == "char" {
+		mysqlType = "char(1)"
+	}
+	if t.Name == "float" && len(t.Args) == 1 {
+		if p, err := strconv.Atoi(t.Args[0]); err == nil && p <= 24 {
+			mysqlType = "float"
+		}
+	}
+	return translatedType{
+		Mysql:         mysqlType,
+		GoType:        postgresGoTypes[t.Name],
+		AutoIncrement: strings.Contains(t.Name, "serial"),
+		Unknown:       !ok,
+	}
+}
+
+// pqArrayType gets the array type of github.com/lib/pq for a one-dimensional
+// array, database/sql can't scan a plain slice.
+func pqArrayType(hint columnHint, tp *types.FieldType) (string, bool) {
+	if hint.ArrayDims != 1 {
+		return "", false
+	}
+	switch arrayElemName(hint) {
+	case "boolean", "bool":
+		return "pq.BoolArray", true
+	case "bytea":
+		return "pq.ByteaArray", true
+	}
+	switch tp.EvalType() {
+	case types.ETInt:
+		return "pq.Int64Array", true
+	case types.ETReal:
+		if tp.Tp == mysql.TypeFloat {
+			return "pq.Float32Array", true
+		}
+		return "pq.Float64Array", true
+	case types.ETString:
+		if tp.Charset == "binary" {
+			return "", false
+		}
+		// text, uuid, enum and others
+		return "pq.StringArray", true
+	}
+	return "", false
+}
+
+func isBoolArray(hint columnHint) bool {
+	name := arrayElemName(hint)
+	return hint.ArrayDims > 0 && (name == "boolean" || name == "bool")
+}
+
+// arrayElemName is the declared type of elements without arguments
+func arrayElemName(hint columnHint) string {
+	name := strings.TrimRight(hint.RawType, "[]")
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// dropCopyData blanks out the data following "COPY ... FROM stdin;" in a
+// pg_dump output, line breaks are kept.
+func dropCopyData(sql string) string {
+	lines := strings.Split(sql, "\n")
+	inData := false
+	for i, line := range lines {
+		if inData {
+			if strings.TrimSpace(line) == `\.` {
+				inData = false
+			}
+			lines[i] = ""
+			continue
+		}
+		s := strings.ToUpper(strings.TrimSpace(line))
+		if strings.HasPrefix(s, "COPY ") && strings.HasSuffix(s, "FROM STDIN;") {
+			inData = true
+		}
+	}
+	return strings.Join(lines, "\n")
+}
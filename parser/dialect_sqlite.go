@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"strings"
+)
+
+var sqliteDialect = dialectSpec{
+	lexer: lexerConfig{
+		identQuotes: "\"`[",
+	},
+	mapType:   sqliteType,
+	fixColumn: sqliteRowid,
+}
+
+// sqliteType maps a declared type by the type affinity rules of SQLite, see
+// https://www.sqlite.org/datatype3.html. Date and boolean types, which have
+// numeric affinity, are recognized by name as drivers do.
+func sqliteType(t declaredType) translatedType {
+	name := strings.ToUpper(t.Name)
+	switch {
+	case name == "DATE":
+		return translatedType{Mysql: "date"}
+	case name == "DATETIME" || name == "TIMESTAMP":
+		return translatedType{Mysql: "datetime"}
+	case name == "BOOLEAN" || name == "BOOL":
+		return translatedType{Mysql: "boolean", GoType: "bool"}
+	case strings.Contains(name, "INT"):
+		return translatedType{Mysql: "bigint"}
+	case strings.Contains(name, "CHAR") || strings.Contains(name, "CLOB") || strings.Contains(name, "TEXT"):
+		return translatedType{Mysql: "text"}
+	case name == "" || strings.Contains(name, "BLOB"):
+		return translatedType{Mysql: "blob", GoType: "[]byte"}
+	case strings.Contains(name, "REAL") || strings.Contains(name, "FLOA") || strings.Contains(name, "DOUB"):
+		return translatedType{Mysql: "double"}
+	default:
+		// numeric affinity stores integers and reals
+		return translatedType{Mysql: "double"}
+	}
+}
+
+// sqliteRowid makes "INTEGER PRIMARY KEY" auto increment, such column is an
+// alias of the rowid.
+func sqliteRowid(c *ddlColumn) {
+	if c.PrimaryKey && strings.EqualFold(c.Type.Name, "integer") {
+		c.AutoIncrement = true
+	}
+}
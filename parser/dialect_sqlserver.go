@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+)
+
+var sqlServerDialect = dialectSpec{
+	lexer: lexerConfig{
+		identQuotes:     `"[`,
+		nationalStrings: true,
+	},
+	preprocess:   replaceBatchSeparator,
+	mapType:      sqlServerType,
+	tableIndexes: true,
+}
+
+// sqlServerTypes maps SQL Server types to MySQL types, arguments of the
+// declared type are kept for types in sqlServerTypesWithArgs.
+var sqlServerTypes = map[string]string{
+	"bigint":           "bigint",
+	"int":              "int",
+	"integer":          "int",
+	"smallint":         "smallint",
+	"tinyint":          "tinyint unsigned",
+	"bit":              "boolean",
+	"decimal":          "decimal",
+	"numeric":          "decimal",
+	"money":            "decimal(19,4)",
+	"smallmoney":       "decimal(10,4)",
+	"float":            "double",
+	"real":             "float",
+	"date":             "date",
+	"time":             "time",
+	"datetime":         "datetime",
+	"datetime2":        "datetime",
+	"smalldatetime":    "datetime",
+	"datetimeoffset":   "datetime",
+	"char":             "char",
+	"nchar":            "char",
+	"varchar":          "varchar",
+	"nvarchar":         "varchar",
+	"text":             "text",
+	"ntext":            "text",
+	"binary":           "blob",
+	"varbinary":        "blob",
+	"image":            "blob",
+	"uniqueidentifier": "char(36)",
+	"xml":              "text",
+	"sql_variant":      "text",
+	"hierarchyid":      "varchar(892)",
+	"rowversion":       "binary(8)",
+	"timestamp":        "binary(8)",
+}
+
+var sqlServerTypesWithArgs = map[string]struct{}{
+	"decimal": {}, "char": {}, "varchar": {},
+}
+
+var sqlServerGoTypes = map[string]string{
+	"bit":        "bool",
+	"binary":     "[]byte",
+	"varbinary":  "[]byte",
+	"image":      "[]byte",
+	"rowversion": "[]byte",
+	"timestamp":  "[]byte",
+}
+
+func sqlServerType(t declaredType) translatedType {
+	mysqlType, ok := sqlServerTypes[t.Name]
+	if !ok {
+		// alias types defined by CREATE TYPE
+		mysqlType = "text"
+	}
+	if len(t.Args) == 1 && t.Args[0] == "max" {
+		// varchar(max) and nvarchar(max) hold up to 2GB
+		if mysqlType == "varchar" {
+			mysqlType = "longtext"
+		}
+	} else if _, keepArgs := sqlServerTypesWithArgs[mysqlType]; keepArgs && len(t.Args) > 0 {
+		mysqlType += "(" + strings.Join(t.Args, ",") + ")"
+	} else if mysqlType == "varchar" || mysqlType == "char" {
+		// the length is 1 if not specified
+		mysqlType += "(1)"
+	}
+	return translatedType{
+		Mysql:   mysqlType,
+		GoType:  sqlServerGoTypes[t.Name],
+		Unknown: !ok,
+	}
+}
+
+var batchSeparator = regexp.MustCompile(`(?im)^[ \t]*GO(?:[ \t]+\d+)?[ \t]*;?[ \t]*$`)
+
+// replaceBatchSeparator replaces GO lines, which separate batches for sqlcmd
+// and SSMS, with semicolons.
+func replaceBatchSeparator(sql string) string {
+	return batchSeparator.ReplaceAllString(sql, ";")
+}
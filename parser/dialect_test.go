@@ -0,0 +1,361 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlPostgres(t *testing.T) {
+	sql := `
+SET statement_timeout = 0;
+CREATE TABLE public.users (
+    id serial PRIMARY KEY,
+    "Email" character varying(255) NOT NULL,
+    score numeric(10,2) DEFAULT 0.5,
+    tags text[],
+    matrix integer[][],
+    profile jsonb,
+    visits bigint DEFAULT nextval('visit_seq'::regclass) NOT NULL,
+    nick varchar(20) DEFAULT 'it''s'::character varying,
+    created_at timestamptz default now(),
+    CONSTRAINT users_email_key UNIQUE ("Email")
+) WITH (fillfactor=70);
+COPY public.users (id, "Email") FROM stdin;
+1	a@b.c
+\.
+CREATE INDEX users_email ON public.users USING btree ("Email");
+`
+	data, err := ParseSql(sql, WithDialect(DialectPostgres), WithNoNullType(), WithGormType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(data.StructCode[0]), "\n")
+	expected := []string{
+		"ID uint `gorm:\"column:id;type:serial;primaryKey;autoIncrement\"`",
+		"Email string `gorm:\"column:Email;type:character varying(255);NOT NULL\"`",
+		"Score string `gorm:\"column:score;type:numeric(10,2);default:0.5\"`",
+		"Tags []string `gorm:\"column:tags;type:text[]\"`",
+		"Matrix [][]int `gorm:\"column:matrix;type:integer[][]\"`",
+		"Profile []byte `gorm:\"column:profile;type:jsonb\"`",
+		"Visits uint64 `gorm:\"column:visits;type:bigint;autoIncrement;NOT NULL\"`",
+		"Nick string `gorm:\"column:nick;type:varchar(20);default:'it's'\"`",
+		"CreatedAt time.Time `gorm:\"column:created_at;type:timestamptz;default:CURRENT_TIMESTAMP\"`",
+	}
+	if assert.Equal(t, len(expected)+2, len(lines)) {
+		for i, s := range expected {
+			assert.Equal(t, s, strings.Join(strings.Fields(lines[i+1]), " "))
+		}
+	}
+	assert.Equal(t, []string{"time"}, data.ImportPath)
+}
+
+func TestParseSqlPostgresAlterTable(t *testing.T) {
+	// keys and serials of pg_dump are added by ALTER TABLE
+	sql := `
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL
+);
+ALTER TABLE public.users OWNER TO postgres;
+CREATE SEQUENCE public.users_id_seq AS integer START WITH 1 INCREMENT BY 1 NO MINVALUE NO MAXVALUE CACHE 1;
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+CREATE TABLE public.orders (
+    id bigint NOT NULL,
+    user_id integer NOT NULL,
+    note text
+);
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+ALTER TABLE public.orders ADD COLUMN total numeric(10,2) NULL, DROP COLUMN note;
+`
+	tables, err := ParseTables(sql, WithDialect(DialectPostgres))
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(tables)) {
+		return
+	}
+	assert.Equal(t, []IndexInfo{
+		{Columns: []string{"id"}, Primary: true},
+		{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+	}, tables[0].Indexes)
+	assert.Equal(t, []ForeignKeyInfo{{Columns: []string{"user_id"}, RefTable: "users", RefColumns: []string{"id"}}}, tables[1].ForeignKeys)
+
+	data, err := ParseSql(sql, WithDialect(DialectPostgres), WithIndexTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "`gorm:\"column:id;primaryKey;autoIncrement\"`")
+	assert.Contains(t, data.StructCode[0], "`gorm:\"column:email;uniqueIndex:users_email_key;NOT NULL\"`")
+	assert.Contains(t, data.StructCode[1], "`gorm:\"column:id;primaryKey\"`")
+	assert.Contains(t, data.StructCode[1], "Total  sql.NullString")
+	assert.NotContains(t, data.StructCode[1], "Note")
+
+	sql = `CREATE TABLE [dbo].[Orders] ([Id] int NOT NULL, [UserId] int NOT NULL)
+GO
+ALTER TABLE [dbo].[Orders] ADD CONSTRAINT [PK_Orders] PRIMARY KEY CLUSTERED ([Id] ASC)
+GO
+ALTER TABLE [dbo].[Orders] WITH CHECK ADD CONSTRAINT [FK_Orders_Users] FOREIGN KEY([UserId]) REFERENCES [dbo].[Users] ([Id])
+GO
+ALTER TABLE [dbo].[Orders] CHECK CONSTRAINT [FK_Orders_Users]
+GO
+ALTER TABLE [dbo].[Orders] ADD [Note] nvarchar(50) NULL, [Total] decimal(10, 2) NULL
+GO
+`
+	tables, err = ParseTables(sql, WithDialect(DialectSQLServer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.True(t, tables[0].Columns[0].PrimaryKey)
+		assert.Equal(t, 1, len(tables[0].ForeignKeys))
+		assert.Equal(t, 4, len(tables[0].Columns))
+	}
+}
+
+func TestParseSqlPostgresArrays(t *testing.T) {
+	sql := `CREATE TABLE posts (
+    tags text[] NOT NULL,
+    ids integer[],
+    flags boolean[],
+    scores real[],
+    refs uuid ARRAY,
+    matrix int[][]
+);`
+	data, err := ParseSql(sql, WithDialect(DialectPostgres))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "Tags   []string  `gorm:\"column:tags;type:text[];NOT NULL\"`")
+	assert.Contains(t, code, "Flags  []bool    `gorm:\"column:flags;type:boolean[]\"`")
+	assert.Contains(t, code, "Matrix [][]int   `gorm:\"column:matrix;type:int[][]\"`")
+
+	data, err = ParseSql(sql, WithDialect(DialectPostgres), WithPqArrays())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(data.StructCode[0]), "\n")
+	expected := []string{
+		"Tags pq.StringArray `gorm:\"column:tags;type:text[];NOT NULL\"`",
+		"Ids pq.Int64Array `gorm:\"column:ids;type:integer[]\"`",
+		"Flags pq.BoolArray `gorm:\"column:flags;type:boolean[]\"`",
+		"Scores pq.Float32Array `gorm:\"column:scores;type:real[]\"`",
+		"Refs pq.StringArray `gorm:\"column:refs;type:uuid[]\"`",
+		"Matrix [][]int `gorm:\"column:matrix;type:int[][]\"`",
+	}
+	if assert.Equal(t, len(expected)+2, len(lines)) {
+		for i, s := range expected {
+			assert.Equal(t, s, strings.Join(strings.Fields(lines[i+1]), " "))
+		}
+	}
+	assert.Equal(t, []string{"github.com/lib/pq"}, data.ImportPath)
+}
+
+func TestTranslatePostgres(t *testing.T) {
+	sql := `CREATE TABLE IF NOT EXISTS "order" (
+  "select" int4 GENERATED BY DEFAULT AS IDENTITY,
+  user_id int8 REFERENCES "user"(id) ON DELETE CASCADE DEFERRABLE,
+  price double precision CHECK (price > 0),
+  PRIMARY KEY ("select")
+);`
+	mysql, hints, _, err := translateDialect(sql, DialectPostgres)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS `order`(\n"+
+		"`select` int AUTO_INCREMENT,\n"+
+		"`user_id` bigint REFERENCES `user`(`id`) ON DELETE CASCADE,\n"+
+		"`price` double,\n"+
+		"PRIMARY KEY(`select`)\n"+
+		");", mysql)
+	assert.Equal(t, "double precision", hints["order"].columns["price"].RawType)
+	assert.Equal(t, []CheckInfo{{Expr: "price > 0", Columns: []string{"price"}}}, hints["order"].checks)
+}
+
+func TestTranslatePostgresIncomplete(t *testing.T) {
+	sql := `CREATE TABLE t (
+  id int4 GENERATED BY DEFAULT,
+  price numeric CHECK (),
+  CHECK ()
+);`
+	mysql, hints, _, err := translateDialect(sql, DialectPostgres)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "CREATE TABLE `t`(\n`id` int,\n`price` decimal\n\n);", mysql)
+		assert.Empty(t, hints["t"].checks)
+	}
+	data, err := ParseSql(sql, WithDialect(DialectPostgres))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.NotContains(t, data.StructCode[0], "primaryKey")
+		assert.NotContains(t, data.StructCode[0], "CHECK")
+	}
+}
+
+func TestTranslateTruncated(t *testing.T) {
+	for _, d := range []Dialect{DialectPostgres, DialectSQLite, DialectSQLServer} {
+		for _, sql := range []string{
+			"CREATE TABLE t (",
+			"CREATE TABLE t (\n  id int,\n  price decimal(10, 2)",
+			"CREATE TABLE t (id int);\nCREATE UNIQUE INDEX i ON t (",
+		} {
+			_, _, _, err := translateDialect(sql, d)
+			var parseErr *ParseError
+			if assert.ErrorAs(t, err, &parseErr, sql) {
+				assert.Equal(t, "unclosed parenthesis", parseErr.Message)
+			}
+		}
+	}
+	_, _, _, err := translateDialect("CREATE TABLE t (id int);\nCREATE INDEX i ON t\n  (id", DialectPostgres)
+	var parseErr *ParseError
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 3, parseErr.Line)
+	}
+}
+
+func TestParseSqlSQLite(t *testing.T) {
+	sql := `PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+CREATE TABLE "notes" (
+  id INTEGER PRIMARY KEY,
+  [title] VARCHAR(64) NOT NULL DEFAULT '',
+  body TEXT,
+  score REAL,
+  amount NUMERIC,
+  data BLOB,
+  extra,
+  created DATETIME DEFAULT (datetime('now')),
+  owner_id INTEGER NOT NULL REFERENCES users(id)
+) WITHOUT ROWID;
+CREATE TABLE counters (seq INTEGER PRIMARY KEY AUTOINCREMENT, n UNSIGNED BIG INT);
+COMMIT;`
+	data, err := ParseSql(sql, WithDialect(DialectSQLite), WithNoNullType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(data.StructCode[0]), "\n")
+	expected := []string{
+		"ID uint `gorm:\"column:id;primaryKey;autoIncrement\"`",
+		"Title string `gorm:\"column:title;NOT NULL\"`",
+		"Body string `gorm:\"column:body\"`",
+		"Score float64 `gorm:\"column:score\"`",
+		"Amount float64 `gorm:\"column:amount\"`",
+		"Data []byte `gorm:\"column:data\"`",
+		"Extra []byte `gorm:\"column:extra\"`",
+		"Created time.Time `gorm:\"column:created;default:CURRENT_TIMESTAMP\"`",
+		"OwnerID int64 `gorm:\"column:owner_id;NOT NULL\"`",
+	}
+	if assert.Equal(t, len(expected)+2, len(lines)) {
+		for i, s := range expected {
+			assert.Equal(t, s, strings.Join(strings.Fields(lines[i+1]), " "))
+		}
+	}
+	assert.Contains(t, data.StructCode[1], "Seq uint  `gorm:\"column:seq;primaryKey;autoIncrement\"`")
+	assert.Contains(t, data.StructCode[1], "N   int64 `gorm:\"column:n\"`")
+}
+
+func TestParseSqlPostgresIndex(t *testing.T) {
+	sql := `CREATE TABLE public.users (id integer NOT NULL, email text NOT NULL, name text);
+CREATE UNIQUE INDEX CONCURRENTLY IF NOT EXISTS users_email ON ONLY public.users USING btree (email);
+CREATE INDEX ON users (name);
+CREATE INDEX users_name_lower ON users (lower(name));
+CREATE INDEX users_id_name ON users (id DESC NULLS LAST, name) WHERE name IS NOT NULL;`
+	data, err := ParseSql(sql, WithDialect(DialectPostgres), WithIndexTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `gorm:"column:id;index:users_id_name,priority:1;NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:email;uniqueIndex:users_email;NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:name;index:users_id_name,priority:2"`)
+}
+
+func TestParseSqlSQLServer(t *testing.T) {
+	sql := `SET ANSI_NULLS ON
+GO
+CREATE TABLE [dbo].[Users](
+	[Id] [int] IDENTITY(1,1) NOT NULL,
+	[Name] [nvarchar](50) NOT NULL CONSTRAINT [DF_Users_Name] DEFAULT (N'it''s'),
+	[Bio] nvarchar(max) NULL,
+	[Guid] uniqueidentifier NOT NULL DEFAULT (newid()),
+	[Active] [bit] NOT NULL DEFAULT ((1)),
+	[Verified] bit NULL,
+	[Level] tinyint NOT NULL,
+	[Balance] money NULL,
+	[CreatedAt] datetime2(7) NOT NULL DEFAULT (getdate()),
+	[Avatar] varbinary(max) NULL,
+	CONSTRAINT [PK_Users] PRIMARY KEY CLUSTERED ([Id] ASC)
+		WITH (PAD_INDEX = OFF, STATISTICS_NORECOMPUTE = OFF) ON [PRIMARY],
+	INDEX [IX_Users_Name] NONCLUSTERED ([Name])
+) ON [PRIMARY] TEXTIMAGE_ON [PRIMARY]
+GO
+CREATE UNIQUE NONCLUSTERED INDEX [UX_Users_Guid] ON [dbo].[Users] ([Guid] ASC)
+GO
+`
+	data, err := ParseSql(sql, WithDialect(DialectSQLServer), WithNullStyle(NullInPointer), WithIndexTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(data.StructCode[0]), "\n")
+	expected := []string{
+		"ID uint `gorm:\"column:Id;primaryKey;autoIncrement\"`",
+		"Name string `gorm:\"column:Name;default:it's;index:IX_Users_Name;NOT NULL\"`",
+		"Bio *string `gorm:\"column:Bio\"`",
+		"Guid string `gorm:\"column:Guid;uniqueIndex:UX_Users_Guid;NOT NULL\"`",
+		"Active bool `gorm:\"column:Active;default:1;NOT NULL\"`",
+		"Verified *bool `gorm:\"column:Verified\"`",
+		"Level uint `gorm:\"column:Level;NOT NULL\"`",
+		"Balance *string `gorm:\"column:Balance\"`",
+		"CreatedAt time.Time `gorm:\"column:CreatedAt;default:CURRENT_TIMESTAMP;NOT NULL\"`",
+		"Avatar []byte `gorm:\"column:Avatar\"`",
+	}
+	// struct and TableName func, gorm takes users as the table of Users
+	if assert.Equal(t, len(expected)+6, len(lines)) {
+		for i, s := range expected {
+			assert.Equal(t, s, strings.Join(strings.Fields(lines[i+1]), " "))
+		}
+	}
+	assert.Contains(t, data.StructCode[0], "return \"Users\"")
+	assert.Equal(t, []string{"time"}, data.ImportPath)
+
+	// uniqueidentifier is a UUID column of WithUUIDType
+	data, err = ParseSql(sql, WithDialect(DialectSQLServer), WithUUIDType("github.com/google/uuid.UUID"))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Regexp(t, "Guid +uuid.UUID ", data.StructCode[0])
+		assert.Equal(t, []string{"database/sql", "github.com/google/uuid", "time"}, data.ImportPath)
+	}
+
+	data, err = ParseSql(sql, WithDialect(DialectSQLServer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "sql.NullBool")
+	}
+}
+
+func TestParseSqlBoolean(t *testing.T) {
+	mysqlSql := "CREATE TABLE t (id int PRIMARY KEY, active bool NOT NULL DEFAULT TRUE, verified BOOLEAN NULL, level tinyint(1) NULL);"
+	pgSql := "CREATE TABLE t (id int PRIMARY KEY, active boolean NOT NULL DEFAULT true, verified bool NULL);"
+	sqliteSql := "CREATE TABLE t (id INTEGER PRIMARY KEY, active BOOLEAN NOT NULL, verified BOOL NULL);"
+	for _, c := range []struct {
+		sql     string
+		dialect Dialect
+	}{{mysqlSql, DialectMySQL}, {pgSql, DialectPostgres}, {sqliteSql, DialectSQLite}} {
+		data, err := ParseSql(c.sql, WithDialect(c.dialect))
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+			assert.Regexp(t, "Active +bool ", data.StructCode[0])
+			assert.Regexp(t, "Verified +sql.NullBool ", data.StructCode[0])
+		}
+		data, err = ParseSql(c.sql, WithDialect(c.dialect), WithNullStyle(NullInPointer))
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+			assert.Regexp(t, "Active +bool ", data.StructCode[0])
+			assert.Regexp(t, `Verified +\*bool `, data.StructCode[0])
+		}
+	}
+
+	// tinyint(1) is an integer unless WithTinyIntBool
+	data, err := ParseSql(mysqlSql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Regexp(t, "Level +sql.NullInt32 ", data.StructCode[0])
+	}
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+// tmplEmbedded is a struct of columns with the same prefix, it's a field of
+// model with gorm tag embedded.
+type tmplEmbedded struct {
+	Name   string
+	Fields []tmplField
+}
+
+// embeddedGroup is the columns of a prefix of WithEmbeddedGroups in a table
+type embeddedGroup struct {
+	Prefix string // prefix of column names, e.g. "u_address_" with column prefix "u_"
+	Index  int    // index of the field of group in fields of model
+	Fields []tmplField
+}
+
+// findEmbeddedPrefix finds the prefix of WithEmbeddedGroups of a column name
+// without column prefix and returns the rest of name, it's empty if no prefix
+// matches.
+func findEmbeddedPrefix(name string, opt options) (prefix string, rest string) {
+	for _, p := range opt.EmbeddedGroups {
+		if !strings.HasSuffix(p, "_") {
+			p += "_"
+		}
+		if len(name) > len(p) && strings.EqualFold(name[:len(p)], p) {
+			return name[:len(p)], name[len(p):]
+		}
+	}
+	return "", ""
+}
+
+// makeEmbedded makes the struct of a group, ok is false if the same struct is
+// declared by another table already.
+func makeEmbedded(name string, fields []tmplField, ctx *parseContext) (tmplEmbedded, bool) {
+	b := strings.Builder{}
+	for _, f := range fields {
+		b.WriteString(f.Name + " " + f.GoType + " " + f.Tag + "\x00")
+	}
+	key := b.String()
+	typeName := name
+	for i := 2; ; i++ {
+		declared, exists := ctx.embedded[typeName]
+		if !exists {
+			break
+		}
+		if declared == key {
+			return tmplEmbedded{Name: typeName}, false
+		}
+		typeName = name + strconv.Itoa(i)
+	}
+	ctx.embedded[typeName] = key
+	return tmplEmbedded{Name: typeName, Fields: fields}, true
+}
+
+// newEmbeddedField makes the field of a group in model, its type is the name
+// of group before makeEmbedded. prefix is in the configured case and
+// columnPrefix is in the case of column.
+func newEmbeddedField(prefix, columnPrefix string, opt options) tmplField {
+	name := toCamel(strings.TrimSuffix(prefix, "_"))
+	field := tmplField{Name: name, GoType: name}
+	if opt.UnexportedFields {
+		field.Name = unexportedName(name)
+	}
+	tags := []string{"gorm", "embedded;embeddedPrefix:" + columnPrefix}
+	name = jsonName(strings.TrimSuffix(prefix, "_"), opt)
+	if opt.JsonTag {
+		tags = append(tags, "json", name)
+	}
+	if opt.YamlTag {
+		tags = append(tags, "yaml", name)
+	}
+	if opt.BsonTag {
+		tags = append(tags, "bson", name)
+	}
+	field.Tag = makeTagStr(tags)
+	return field
+}
+
+// isForeignKeyColumn reports whether the column is in a foreign key
+func isForeignKeyColumn(table TableInfo, column string) bool {
+	for _, key := range table.ForeignKeys {
+		for _, c := range key.Columns {
+			if strings.EqualFold(c, column) {
+				return true
+			}
+		}
+	}
+	return false
+}
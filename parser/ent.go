@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"fmt"
+	"go/format"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/jinzhu/inflection"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+	"github.com/pkg/errors"
+)
+
+var entSchemaTmpl = template.Must(template.New("entSchema").Parse(`
+{{- if .Comment}}
+{{- range .Comment}}// {{.}}
+{{end}}
+{{- else}}// {{.TableName}} holds the schema definition for the {{.TableName}} entity.
+{{end -}}
+type {{.TableName}} struct {
+	ent.Schema
+}
+{{if .NameFunc}}
+// Annotations of the {{.TableName}}.
+func ({{.TableName}}) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "{{.RawTableName}}"},
+	}
+}
+{{end}}
+// Fields of the {{.TableName}}.
+func ({{.TableName}}) Fields() []ent.Field {
+	return []ent.Field{
+{{- range .Fields}}
+		{{.}},
+{{- end}}
+	}
+}
+`))
+
+type entData struct {
+	TableName    string
+	RawTableName string
+	NameFunc     bool
+	Comment      []string
+	Fields       []string
+}
+
+// makeEnt makes an ent schema for table, fields are built by the field package
+// of ent.
+func makeEnt(table TableInfo, opt options) (string, []string, error) {
+	data := entData{
+		TableName:    structName(table.Name, opt),
+		RawTableName: table.Name,
+		Comment:      commentLines(table.Comment),
+		Fields:       make([]string, 0, len(table.Columns)),
+	}
+	// ent names the table in plural snake case as gorm does
+	data.NameFunc = !opt.NoTableName && (opt.ForceTableName || data.RawTableName != inflection.Plural(toSnake(data.TableName)))
+	importPath := []string{"entgo.io/ent", "entgo.io/ent/schema/field"}
+	if data.NameFunc {
+		importPath = append(importPath, "entgo.io/ent/dialect/entsql", "entgo.io/ent/schema")
+	}
+	for _, col := range table.Columns {
+		name := trimColumnPrefix(col.Name, opt)
+		if matchColumn(name, opt.ExcludeColumns) {
+			continue
+		}
+		f, pkg := entField(col, name, opt)
+		data.Fields = append(data.Fields, f)
+		importPath = append(importPath, pkg...)
+	}
+
+	builder := strings.Builder{}
+	if err := entSchemaTmpl.Execute(&builder, data); err != nil {
+		return "", nil, err
+	}
+	code, err := format.Source([]byte(builder.String()))
+	if err != nil {
+		return builder.String(), importPath, errors.WithMessage(err, "format golang code error")
+	}
+	return string(code), importPath, nil
+}
+
+// entField makes a field of ent schema like field.String("email").NotEmpty()
+func entField(col ColumnInfo, name string, opt options) (string, []string) {
+	var importPath []string
+	goType, _ := mysqlToGoType(col.tp, NullDisable)
+	if t, ok := sizedUnsignedType(col.tp); ok && opt.UnsignedTypes {
+		goType = t
+	}
+	if isTinyIntBool(col.tp, opt) || col.hint.GoType == "bool" {
+		goType = "bool"
+	}
+
+	b := strings.Builder{}
+	switch {
+	case col.hint.ArrayDims > 0:
+		goType = strings.Repeat("[]", col.hint.ArrayDims) + goType
+		fmt.Fprintf(&b, "field.JSON(%q, %s{})", name, goType)
+		if strings.Contains(goType, "time.") {
+			importPath = append(importPath, "time")
+		}
+	case col.tp.Tp == mysql.TypeEnum:
+		fmt.Fprintf(&b, "field.Enum(%q)", name)
+		if len(col.Elems) > 0 {
+			values := make([]string, 0, len(col.Elems))
+			for _, e := range col.Elems {
+				values = append(values, strconv.Quote(e))
+			}
+			fmt.Fprintf(&b, ".Values(%s)", strings.Join(values, ", "))
+		}
+	case col.tp.Tp == mysql.TypeSet:
+		fmt.Fprintf(&b, "field.Strings(%q)", name)
+	case col.tp.Tp == mysql.TypeJSON:
+		fmt.Fprintf(&b, "field.JSON(%q, json.RawMessage{})", name)
+		importPath = append(importPath, "encoding/json")
+	case col.hint.GoType == "[]byte" || goType == "[]byte":
+		goType = "[]byte"
+		fmt.Fprintf(&b, "field.Bytes(%q)", name)
+	case goType == "string" && isTextType(col.tp.Tp):
+		fmt.Fprintf(&b, "field.Text(%q)", name)
+	default:
+		fmt.Fprintf(&b, "field.%s(%q)", entFieldFunc(goType), name)
+	}
+
+	isChar := goType == "string" && isCharType(col.tp.Tp)
+	if isChar && col.tp.Flen > 0 {
+		fmt.Fprintf(&b, ".MaxLen(%d)", col.tp.Flen)
+	}
+	if col.Unique {
+		b.WriteString(".Unique()")
+	}
+	if v, pkg := entDefault(col, goType); v != "" {
+		fmt.Fprintf(&b, ".Default(%s)", v)
+		if pkg != "" {
+			importPath = append(importPath, pkg)
+		}
+	}
+	if col.Nullable {
+		b.WriteString(".Optional()")
+	} else if (isChar || goType == "string" && isTextType(col.tp.Tp)) && !col.HasDefault && !col.PrimaryKey {
+		b.WriteString(".NotEmpty()")
+	}
+	if col.Comment != "" {
+		fmt.Fprintf(&b, ".Comment(%q)", strings.Join(commentLines(col.Comment), " "))
+	}
+	if name != col.Name {
+		fmt.Fprintf(&b, ".StorageKey(%q)", col.Name)
+	}
+	return b.String(), importPath
+}
+
+// entFieldFunc gets the function of ent field package for the Go type
+func entFieldFunc(goType string) string {
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return strings.ToUpper(goType[:1]) + goType[1:]
+	case "float64":
+		return "Float"
+	case "float32":
+		return "Float32"
+	case "bool":
+		return "Bool"
+	case "time.Time":
+		return "Time"
+	}
+	// decimal and unsupported types are strings
+	return "String"
+}
+
+// entDefault gets the default value of field in Go, it's empty if the
+// default value can't be used for the type.
+func entDefault(col ColumnInfo, goType string) (string, string) {
+	if !col.HasDefault || col.Default == "" && !col.DefaultIsString {
+		return "", ""
+	}
+	v := col.Default
+	switch goType {
+	case "int", "int8", "int16", "int32", "int64":
+		if _, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return v, ""
+		}
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		if _, err := strconv.ParseUint(v, 10, 64); err == nil {
+			return v, ""
+		}
+	case "float64", "float32":
+		if _, err := strconv.ParseFloat(v, 64); err == nil {
+			return v, ""
+		}
+	case "bool":
+		switch strings.ToLower(v) {
+		case "1", "true":
+			return "true", ""
+		case "0", "false":
+			return "false", ""
+		}
+	case "string":
+		if col.DefaultIsString {
+			return strconv.Quote(v), ""
+		}
+	case "time.Time":
+		if i := strings.IndexByte(v, '('); i >= 0 {
+			// CURRENT_TIMESTAMP(3)
+			v = v[:i]
+		}
+		switch strings.ToUpper(v) {
+		case "CURRENT_TIMESTAMP", "NOW", "LOCALTIMESTAMP":
+			return "time.Now", "time"
+		}
+	}
+	return "", ""
+}
+
+func isCharType(tp byte) bool {
+	return tp == mysql.TypeString || tp == mysql.TypeVarchar || tp == mysql.TypeVarString
+}
+
+func isTextType(tp byte) bool {
+	switch tp {
+	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		return true
+	}
+	return false
+}
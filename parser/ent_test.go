@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlEnt(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  email varchar(255) NOT NULL UNIQUE COMMENT 'login email',
+  nick varchar(32) NOT NULL DEFAULT '',
+  age int unsigned NOT NULL DEFAULT 18,
+  status enum('active','banned') NOT NULL DEFAULT 'active',
+  created_at datetime(3) NULL DEFAULT CURRENT_TIMESTAMP(3)
+);`
+	buf := bytes.Buffer{}
+	err := ParseSqlToWrite(sql, &buf, WithTarget(TargetEnt), WithPackage("schema"), WithSingularStruct())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"time"
+)
+
+// User holds the schema definition for the User entity.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.Int64("id"),
+		field.String("email").MaxLen(255).Unique().NotEmpty().Comment("login email"),
+		field.String("nick").MaxLen(32).Default(""),
+		field.Uint("age").Default(18),
+		field.Enum("status").Values("active", "banned").Default("active"),
+		field.Time("created_at").Default(time.Now).Optional(),
+	}
+}
+`, buf.String())
+}
+
+func TestParseSqlEntTableName(t *testing.T) {
+	sql := `CREATE TABLE t_user (
+  u_id int PRIMARY KEY,
+  u_data blob NOT NULL,
+  u_flag tinyint(1) NOT NULL DEFAULT 0
+);`
+	data, err := ParseSql(sql, WithTarget(TargetEnt), WithTablePrefix("t_"), WithColumnPrefix("u_"), WithTinyIntBool())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `entsql.Annotation{Table: "t_user"}`)
+	assert.Contains(t, code, `field.Int("id").StorageKey("u_id"),`)
+	assert.Contains(t, code, `field.Bytes("data").StorageKey("u_data"),`)
+	assert.Contains(t, code, `field.Bool("flag").Default(false).StorageKey("u_flag"),`)
+	assert.True(t, strings.HasPrefix(code, "// User holds"))
+	assert.Equal(t, []string{"entgo.io/ent", "entgo.io/ent/dialect/entsql", "entgo.io/ent/schema", "entgo.io/ent/schema/field"}, data.ImportPath)
+}
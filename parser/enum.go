@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+)
+
+type tmplEnum struct {
+	Name   string
+	Values []tmplEnumValue
+}
+
+type tmplEnumValue struct {
+	Name  string
+	Value string
+}
+
+// makeEnum makes a named string type for an enum column, ok is false if the
+// same type is declared by another table already.
+func makeEnum(name string, elems []string, ctx *parseContext) (tmplEnum, bool) {
+	key := strings.Join(elems, "\x00")
+	typeName := name
+	for i := 2; ; i++ {
+		declared, exists := ctx.enums[typeName]
+		if !exists {
+			break
+		}
+		if declared == key {
+			return tmplEnum{Name: typeName}, false
+		}
+		typeName = name + strconv.Itoa(i)
+	}
+	ctx.enums[typeName] = key
+
+	enum := tmplEnum{
+		Name:   typeName,
+		Values: make([]tmplEnumValue, 0, len(elems)),
+	}
+	used := make(map[string]struct{}, len(elems))
+	for _, e := range elems {
+		constName := typeName + toCamel(e)
+		if constName == typeName {
+			constName += "Empty"
+		}
+		if _, ok := used[constName]; ok {
+			base := constName
+			for i := 2; ; i++ {
+				constName = base + strconv.Itoa(i)
+				if _, ok := used[constName]; !ok {
+					break
+				}
+			}
+		}
+		used[constName] = struct{}{}
+		enum.Values = append(enum.Values, tmplEnumValue{Name: constName, Value: e})
+	}
+	return enum, true
+}
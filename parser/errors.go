@@ -0,0 +1,65 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ParseError is an error in SQL, Line and Column start from 1, they are 0 if
+// unknown.
+type ParseError struct {
+	File    string // set by ParseSqlFiles
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	var msg string
+	switch {
+	case e.Column > 0:
+		msg = fmt.Sprintf("line %d column %d: %s", e.Line, e.Column, e.Message)
+	case e.Line > 0:
+		msg = fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	default:
+		msg = e.Message
+	}
+	if e.File != "" {
+		return e.File + ": " + msg
+	}
+	return msg
+}
+
+func parseErrorf(line int, format string, a ...interface{}) *ParseError {
+	return &ParseError{Line: line, Message: fmt.Sprintf(format, a...)}
+}
+
+var sqlErrorRegexp = regexp.MustCompile(`(?s)^line (\d+) column (\d+) near "(.*)"`)
+
+// maxNearLength limits the text near a syntax error in message
+const maxNearLength = 40
+
+// toParseError converts a syntax error of the MySQL parser to ParseError
+func toParseError(err error) error {
+	m := sqlErrorRegexp.FindStringSubmatch(err.Error())
+	if m == nil {
+		return &ParseError{Message: err.Error()}
+	}
+	line, _ := strconv.Atoi(m[1])
+	column, _ := strconv.Atoi(m[2])
+	// the text after the error position, its first line is enough
+	near := strings.TrimSpace(m[3])
+	if i := strings.IndexByte(near, '\n'); i >= 0 {
+		near = strings.TrimSpace(near[:i])
+	}
+	if r := []rune(near); len(r) > maxNearLength {
+		near = string(r[:maxNearLength]) + "..."
+	}
+	msg := "syntax error"
+	if near != "" {
+		msg += fmt.Sprintf(" near %q", near)
+	}
+	return &ParseError{Line: line, Column: column, Message: msg}
+}
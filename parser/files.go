@@ -0,0 +1,91 @@
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SqlFiles is SQL joined from files
+type SqlFiles struct {
+	Sql   string
+	files []sqlFile
+}
+
+type sqlFile struct {
+	name      string
+	startLine int // line of the file in SQL, start from 1
+}
+
+// ReadSqlFiles reads and joins SQL files in order, a path can be a glob
+// pattern like "schema/*.sql", "-" reads the standard input.
+func ReadSqlFiles(paths []string) (SqlFiles, error) {
+	var result SqlFiles
+	b := strings.Builder{}
+	line := 1
+	for _, p := range paths {
+		names := []string{p}
+		if strings.ContainsAny(p, "*?[") {
+			var err error
+			names, err = filepath.Glob(p)
+			if err != nil {
+				return result, errors.WithMessage(err, p)
+			}
+			if len(names) == 0 {
+				return result, errors.Errorf("no file matches %s", p)
+			}
+		}
+		for _, name := range names {
+			var data []byte
+			var err error
+			if name == "-" {
+				name = "stdin"
+				data, err = ioutil.ReadAll(os.Stdin)
+			} else {
+				data, err = ioutil.ReadFile(name)
+			}
+			if err != nil {
+				return result, err
+			}
+			result.files = append(result.files, sqlFile{name: name, startLine: line})
+			// a statement is not continued in the next file
+			s := string(data) + "\n;\n"
+			b.WriteString(s)
+			line += strings.Count(s, "\n")
+		}
+	}
+	result.Sql = b.String()
+	return result, nil
+}
+
+// Locate sets the file and the line in the file of a ParseError, other errors
+// are returned as is.
+func (f SqlFiles) Locate(err error) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) || pe.Line <= 0 || pe.File != "" {
+		return err
+	}
+	for i := len(f.files) - 1; i >= 0; i-- {
+		if pe.Line >= f.files[i].startLine {
+			located := *pe
+			located.File = f.files[i].name
+			located.Line = pe.Line - f.files[i].startLine + 1
+			return &located
+		}
+	}
+	return err
+}
+
+// ParseSqlFiles parses SQL files together, so that a table can reference
+// tables in other files.
+func ParseSqlFiles(paths []string, options ...Option) (ModelCodes, error) {
+	files, err := ReadSqlFiles(paths)
+	if err != nil {
+		return ModelCodes{}, err
+	}
+	data, err := ParseSql(files.Sql, options...)
+	return data, files.Locate(err)
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sql2gorm")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"1_users.sql":  "CREATE TABLE users (\n  id bigint PRIMARY KEY,\n  created_at datetime NOT NULL\n)",
+		"2_orders.sql": "CREATE TABLE orders (\n  id bigint PRIMARY KEY,\n  user_id bigint REFERENCES users(id),\n  paid_at datetime NULL\n);\n",
+		"bad.txt":      "\nCREATE TABLE bad (\n  id int,\n  name varchar(3) NOT NUL\n);\n",
+	}
+	for name, sql := range files {
+		if !assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(sql), 0666)) {
+			return
+		}
+	}
+
+	data, err := ParseSqlFiles([]string{filepath.Join(dir, "*.sql")}, WithAssociations())
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "type Users struct")
+		assert.Contains(t, data.StructCode[1], "User   Users")
+		assert.Equal(t, []string{"database/sql", "time"}, data.ImportPath)
+	}
+
+	bad := filepath.Join(dir, "bad.txt")
+	_, err = ParseSqlFiles([]string{filepath.Join(dir, "1_users.sql"), bad})
+	if assert.Error(t, err) {
+		assert.Equal(t, bad+": line 4 column 26: syntax error near \");\"", err.Error())
+	}
+
+	_, err = ParseSqlFiles([]string{filepath.Join(dir, "*.csv")})
+	assert.Error(t, err)
+}
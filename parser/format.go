@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"bytes"
+	goast "go/ast"
+	"go/format"
+	goparser "go/parser"
+	gotoken "go/token"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// formatCode removes unused imports from a generated file and formats it
+// like gofmt. The source is returned as is with an error if it can't be
+// formatted.
+func formatCode(src []byte) ([]byte, error) {
+	fset := gotoken.NewFileSet()
+	file, err := goparser.ParseFile(fset, "", src, goparser.ParseComments)
+	if err != nil {
+		return src, errors.WithMessage(err, "format golang code error")
+	}
+	removeUnusedImports(file)
+	buf := bytes.Buffer{}
+	if err := format.Node(&buf, fset, file); err != nil {
+		return src, errors.WithMessage(err, "format golang code error")
+	}
+	return buf.Bytes(), nil
+}
+
+func removeUnusedImports(file *goast.File) {
+	used := make(map[string]struct{})
+	goast.Inspect(file, func(n goast.Node) bool {
+		if sel, ok := n.(*goast.SelectorExpr); ok {
+			if id, ok := sel.X.(*goast.Ident); ok {
+				used[id.Name] = struct{}{}
+			}
+		}
+		return true
+	})
+	decls := file.Decls[:0]
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*goast.GenDecl)
+		if !ok || gen.Tok != gotoken.IMPORT {
+			decls = append(decls, decl)
+			continue
+		}
+		specs := gen.Specs[:0]
+		for _, spec := range gen.Specs {
+			imp := spec.(*goast.ImportSpec)
+			path, _ := strconv.Unquote(imp.Path.Value)
+			name := importName(path)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			if _, ok := used[name]; ok || name == "_" || name == "." {
+				specs = append(specs, spec)
+			}
+		}
+		gen.Specs = specs
+		if len(specs) > 0 {
+			decls = append(decls, gen)
+		}
+	}
+	file.Decls = decls
+	imports := file.Imports[:0]
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*goast.GenDecl); ok && gen.Tok == gotoken.IMPORT {
+			for _, spec := range gen.Specs {
+				imports = append(imports, spec.(*goast.ImportSpec))
+			}
+		}
+	}
+	file.Imports = imports
+}
+
+// withLineEnding ends lines of a generated file with ending, CRLF from the
+// input is replaced as well. Lines end with LF if ending is empty.
+func withLineEnding(code []byte, ending string) []byte {
+	code = bytes.ReplaceAll(code, []byte("\r\n"), []byte("\n"))
+	if ending == "" || ending == "\n" {
+		return code
+	}
+	return bytes.ReplaceAll(code, []byte("\n"), []byte(ending))
+}
+
+// importName guesses the package name of an import path, e.g. yaml for
+// gopkg.in/yaml.v2 and redis for github.com/go-redis/redis/v8.
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && isMajorVersion(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
@@ -0,0 +1,134 @@
+package parser
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+)
+
+const graphQLDateTime = "DateTime"
+
+var (
+	graphQLTypeTmpl = template.Must(template.New("graphQLType").Parse(`
+{{- if .Comment -}}
+"""
+{{range .Comment}}{{.}}
+{{end -}}
+"""
+{{end -}}
+type {{.TableName}} {
+{{- range .Fields}}
+{{- if .Doc}}
+  """
+{{- range .Doc}}
+  {{.}}
+{{- end}}
+  """
+{{- end}}
+  {{.Name}}: {{.GoType}}
+{{- end}}
+}
+`))
+	// scalars used by types are declared in ImportPath
+	graphQLFileTmpl = template.Must(template.New("graphQLFile").Parse(`# Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+{{- if .Source}}
+# source: {{.Source}}
+{{- end}}
+{{if .ImportPath}}
+{{range .ImportPath}}scalar {{.}}
+{{end}}
+{{- end}}
+{{- range .StructCode}}
+{{.}}{{end}}`))
+)
+
+// makeGraphQL makes an object type of GraphQL SDL for table, NOT NULL fields
+// are non-null and a single primary key is ID. The custom scalars used by the
+// type are returned, e.g. DateTime.
+func makeGraphQL(table TableInfo, opt options) (string, []string, error) {
+	data := tmplData{
+		TableName: structName(table.Name, opt),
+		Fields:    make([]tmplField, 0, len(table.Columns)),
+		Comment:   graphQLDescription(commentLines(table.Comment)),
+	}
+	keys := 0
+	for _, col := range table.Columns {
+		if col.PrimaryKey {
+			keys++
+		}
+	}
+	var scalars []string
+	for _, col := range table.Columns {
+		name := trimColumnPrefix(col.Name, opt)
+		if matchColumn(name, opt.ExcludeColumns) {
+			continue
+		}
+		tp := graphQLType(col, opt)
+		if keys == 1 && col.PrimaryKey {
+			tp = "ID"
+		}
+		if tp == graphQLDateTime {
+			scalars = append(scalars, graphQLDateTime)
+		}
+		if col.hint.ArrayDims > 0 || col.tp.Tp == mysql.TypeSet {
+			// elements of an array are not null
+			tp = "[" + tp + "!]"
+		}
+		if !col.Nullable {
+			tp += "!"
+		}
+		data.Fields = append(data.Fields, tmplField{
+			Name:   graphQLFieldName(name, opt),
+			GoType: tp,
+			Doc:    graphQLDescription(commentLines(col.Comment)),
+		})
+	}
+	builder := strings.Builder{}
+	if err := graphQLTypeTmpl.Execute(&builder, data); err != nil {
+		return "", nil, err
+	}
+	return builder.String(), scalars, nil
+}
+
+// graphQLType maps a column to a built-in scalar of GraphQL or DateTime, Int
+// is 32-bit by the spec but gqlgen binds it to int. Decimals are strings to
+// keep the precision.
+func graphQLType(col ColumnInfo, opt options) string {
+	if isTinyIntBool(col.tp, opt) || col.hint.GoType == "bool" {
+		return "Boolean"
+	}
+	if col.hint.GoType == "[]byte" {
+		return "String"
+	}
+	switch col.tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+		return "Int"
+	case mysql.TypeFloat, mysql.TypeDouble:
+		return "Float"
+	case mysql.TypeBit:
+		if col.tp.Flen <= 1 {
+			return "Boolean"
+		}
+		return "Int"
+	case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate:
+		return graphQLDateTime
+	}
+	// string, decimal, enum, json, time and others
+	return "String"
+}
+
+// graphQLFieldName names a field by the style of WithGraphQLFieldStyle, lower
+// camel case by default.
+func graphQLFieldName(column string, opt options) string {
+	opt.JsonTagStyle = opt.GraphQLFieldStyle
+	return jsonName(column, opt)
+}
+
+// graphQLDescription escapes the lines of a block string
+func graphQLDescription(lines []string) []string {
+	for i, l := range lines {
+		lines[i] = strings.ReplaceAll(l, `"""`, `\"""`)
+	}
+	return lines
+}
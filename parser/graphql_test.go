@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlGraphQL(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  ip_address varchar(45) NOT NULL COMMENT 'last login ip',
+  is_admin bool NOT NULL,
+  score double NULL,
+  created_at datetime NULL,
+  balance decimal(10,2) NULL
+) COMMENT 'all users';`
+	buf := bytes.Buffer{}
+	err := ParseSqlToWrite(sql, &buf, WithTarget(TargetGraphQL))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `# Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+
+scalar DateTime
+
+"""
+all users
+"""
+type Users {
+  id: ID!
+  """
+  last login ip
+  """
+  ipAddress: String!
+  isAdmin: Boolean!
+  score: Float
+  createdAt: DateTime
+  balance: String
+}
+`, buf.String())
+
+	data, err := ParseSql(sql, WithTarget(TargetGraphQL), WithGraphQLFieldStyle(JsonSnakeCase))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "  ip_address: String!\n")
+		assert.Equal(t, []string{"DateTime"}, data.ImportPath)
+	}
+}
+
+func TestParseSqlGraphQLKeys(t *testing.T) {
+	sql := `CREATE TABLE user_tags (user_id int NOT NULL, tag_id int NOT NULL, PRIMARY KEY (user_id, tag_id));
+CREATE TABLE posts (id serial PRIMARY KEY, tags text[] NOT NULL);`
+	data, err := ParseSql(sql, WithTarget(TargetGraphQL), WithDialect(DialectPostgres))
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	// a composite key isn't an ID
+	assert.Contains(t, data.StructCode[0], "  userId: Int!\n  tagId: Int!\n")
+	assert.Contains(t, data.StructCode[1], "  id: ID!\n  tags: [String!]!\n")
+	assert.Empty(t, data.ImportPath)
+}
@@ -0,0 +1,194 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"text/template"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+)
+
+// jsonSchemaFileTmpl writes a schema per file, JSON has no comment for the
+// generated marker.
+var jsonSchemaFileTmpl = template.Must(template.New("jsonSchemaFile").Parse(`
+{{- range .StructCode}}{{.}}
+{{end}}`))
+
+// jsonSchema is the subset of JSON Schema used for tables, it's also a schema
+// object of OpenAPI 3.1.
+type jsonSchema struct {
+	Title       string         `json:"title,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Type        interface{}    `json:"type,omitempty"` // a string, or a list with "null"
+	Format      string         `json:"format,omitempty"`
+	Enum        []string       `json:"enum,omitempty"`
+	MaxLength   int            `json:"maxLength,omitempty"`
+	Minimum     *int           `json:"minimum,omitempty"`
+	ReadOnly    bool           `json:"readOnly,omitempty"`
+	Items       *jsonSchema    `json:"items,omitempty"`
+	Properties  jsonProperties `json:"properties,omitempty"`
+	Required    []string       `json:"required,omitempty"`
+}
+
+type jsonProperty struct {
+	Name  string
+	Value interface{}
+}
+
+// jsonProperties is an object which keeps the order of keys
+type jsonProperties []jsonProperty
+
+func (p jsonProperties) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, prop := range p {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(prop.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// makeJSONSchema makes an object schema for table, properties are named like
+// the json tag and NOT NULL columns are required.
+func makeJSONSchema(table TableInfo, opt options) (string, error) {
+	schema := jsonSchema{
+		Title:       structName(table.Name, opt),
+		Description: table.Comment,
+		Type:        "object",
+		Properties:  make(jsonProperties, 0, len(table.Columns)),
+	}
+	for _, col := range table.Columns {
+		name := trimColumnPrefix(col.Name, opt)
+		if matchColumn(name, opt.ExcludeColumns) {
+			continue
+		}
+		name = jsonName(name, opt)
+		if col.ann.Json != "" {
+			name = col.ann.Json
+		}
+		prop := jsonColumnSchema(col, opt)
+		prop.Description = col.Comment
+		prop.ReadOnly = col.Generated != "" || table.View
+		if col.nullDeclared && opt.NullStyle != NullDisable {
+			if tp, ok := prop.Type.(string); ok {
+				prop.Type = []string{tp, "null"}
+			}
+		}
+		schema.Properties = append(schema.Properties, jsonProperty{Name: name, Value: prop})
+		if !col.Nullable {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	b, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// jsonColumnSchema maps a column to the type and format of JSON Schema by its
+// type in Go, formats are the ones of OpenAPI.
+func jsonColumnSchema(col ColumnInfo, opt options) jsonSchema {
+	schema := jsonItemSchema(col, opt)
+	dims := col.hint.ArrayDims
+	if col.tp.Tp == mysql.TypeSet {
+		// values are split into []string
+		dims++
+	}
+	for i := 0; i < dims; i++ {
+		items := schema
+		schema = jsonSchema{Type: "array", Items: &items}
+	}
+	return schema
+}
+
+func jsonItemSchema(col ColumnInfo, opt options) jsonSchema {
+	if isTinyIntBool(col.tp, opt) || col.hint.GoType == "bool" {
+		return jsonSchema{Type: "boolean"}
+	}
+	if col.hint.GoType == "[]byte" {
+		return jsonSchema{Type: "string", Format: "byte"}
+	}
+	if isUUIDType(col) && col.tp.Charset != "binary" {
+		return jsonSchema{Type: "string", Format: "uuid"}
+	}
+	unsigned := mysql.HasUnsignedFlag(col.tp.Flag)
+	switch col.tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+		schema := jsonSchema{Type: "integer", Format: "int32"}
+		if col.tp.Tp == mysql.TypeLonglong || col.tp.Tp == mysql.TypeLong && unsigned {
+			schema.Format = "int64"
+		}
+		if unsigned {
+			schema.Minimum = new(int)
+		}
+		return schema
+	case mysql.TypeFloat:
+		return jsonSchema{Type: "number", Format: "float"}
+	case mysql.TypeDouble:
+		return jsonSchema{Type: "number", Format: "double"}
+	case mysql.TypeBit:
+		if col.tp.Flen <= 1 {
+			return jsonSchema{Type: "boolean"}
+		}
+		return jsonSchema{Type: "integer", Format: "int64", Minimum: new(int)}
+	case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate:
+		// time.Time is encoded in RFC 3339, dates as well
+		return jsonSchema{Type: "string", Format: "date-time"}
+	case mysql.TypeDuration:
+		if opt.TimeAsDuration {
+			// nanoseconds
+			return jsonSchema{Type: "integer", Format: "int64"}
+		}
+	case mysql.TypeEnum, mysql.TypeSet:
+		return jsonSchema{Type: "string", Enum: col.Elems}
+	case mysql.TypeJSON:
+		if opt.JSONDatatype {
+			// any value
+			return jsonSchema{}
+		}
+	}
+	if col.tp.Charset == "binary" && (isCharType(col.tp.Tp) || isTextType(col.tp.Tp)) {
+		return jsonSchema{Type: "string", Format: "byte"}
+	}
+	schema := jsonSchema{Type: "string"}
+	if isCharType(col.tp.Tp) && col.tp.Flen > 0 {
+		schema.MaxLength = col.tp.Flen
+	}
+	// decimal, text, time and others
+	return schema
+}
+
+// writeJSONSchemas writes schemas of tables in components.schemas of OpenAPI,
+// they are keyed by the struct names.
+func writeJSONSchemas(w io.Writer, tables []tableCode, opt options) error {
+	schemas := make(jsonProperties, 0, len(tables))
+	for _, t := range tables {
+		schemas = append(schemas, jsonProperty{Name: structName(t.Name, opt), Value: json.RawMessage(t.Code)})
+	}
+	doc := jsonProperties{{
+		Name:  "components",
+		Value: jsonProperties{{Name: "schemas", Value: schemas}},
+	}}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = w.Write(b)
+	return err
+}
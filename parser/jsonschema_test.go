@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlJSONSchema(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  email varchar(255) NOT NULL COMMENT 'login email',
+  nickname varchar(32) NULL,
+  role enum('admin','user') NOT NULL DEFAULT 'user',
+  created_at datetime NOT NULL
+) COMMENT 'all users';`
+	data, err := ParseSql(sql, WithTarget(TargetJSONSchema), WithJsonTagStyle(JsonCamelCase))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Equal(t, `{
+  "title": "Users",
+  "description": "all users",
+  "type": "object",
+  "properties": {
+    "id": {
+      "type": "integer",
+      "format": "int64",
+      "minimum": 0
+    },
+    "email": {
+      "description": "login email",
+      "type": "string",
+      "maxLength": 255
+    },
+    "nickname": {
+      "type": [
+        "string",
+        "null"
+      ],
+      "maxLength": 32
+    },
+    "role": {
+      "type": "string",
+      "enum": [
+        "admin",
+        "user"
+      ]
+    },
+    "createdAt": {
+      "type": "string",
+      "format": "date-time"
+    }
+  },
+  "required": [
+    "id",
+    "email",
+    "role",
+    "createdAt"
+  ]
+}`, data.StructCode[0])
+
+	buf := bytes.Buffer{}
+	err = ParseSqlToWrite(sql+"CREATE TABLE tags (id int NOT NULL PRIMARY KEY);", &buf, WithTarget(TargetJSONSchema))
+	if !assert.NoError(t, err) {
+		return
+	}
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Type     string   `json:"type"`
+				Required []string `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc)) {
+		schemas := doc.Components.Schemas
+		assert.Equal(t, 2, len(schemas))
+		assert.Equal(t, "object", schemas["Tags"].Type)
+		assert.Equal(t, []string{"id", "email", "role", "created_at"}, schemas["Users"].Required)
+	}
+}
+
+func TestParseSqlJSONSchemaTypes(t *testing.T) {
+	sql := `CREATE TABLE types (
+  a tinyint NOT NULL,
+  b int unsigned NOT NULL,
+  c double NOT NULL,
+  d decimal(10,2) NOT NULL,
+  e tinyint(1) NOT NULL,
+  f bit(8) NOT NULL,
+  g text NOT NULL,
+  h blob NOT NULL,
+  i char(36) NOT NULL,
+  j date NOT NULL,
+  k time NOT NULL,
+  l year NOT NULL,
+  m set('x','y') NOT NULL,
+  n json NOT NULL,
+  o int NOT NULL GENERATED ALWAYS AS (a + 1) VIRTUAL
+);`
+	data, err := ParseSql(sql, WithTarget(TargetJSONSchema), WithTinyIntBool(), WithJSONDatatype())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	var schema struct {
+		Properties map[string]map[string]interface{} `json:"properties"`
+	}
+	if !assert.NoError(t, json.Unmarshal([]byte(data.StructCode[0]), &schema)) {
+		return
+	}
+	props := schema.Properties
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32"}, props["a"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0.0}, props["b"])
+	assert.Equal(t, map[string]interface{}{"type": "number", "format": "double"}, props["c"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, props["d"])
+	assert.Equal(t, map[string]interface{}{"type": "boolean"}, props["e"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0.0}, props["f"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, props["g"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "byte"}, props["h"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "uuid"}, props["i"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, props["j"])
+	assert.Equal(t, map[string]interface{}{"type": "string"}, props["k"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32"}, props["l"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{
+		"type": "string", "enum": []interface{}{"x", "y"}}}, props["m"])
+	assert.Equal(t, map[string]interface{}{}, props["n"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32", "readOnly": true}, props["o"])
+
+	data, err = ParseSql(`CREATE TABLE posts (id serial PRIMARY KEY, tags text[] NULL);`,
+		WithTarget(TargetJSONSchema), WithDialect(DialectPostgres))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `"tags": {
+      "type": [
+        "array",
+        "null"
+      ],
+      "items": {
+        "type": "string"
+      }
+    }`)
+	}
+}
+
+func TestParseSqlToFilesJSONSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sql2gorm")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	sql := "CREATE TABLE user_tags (id int NOT NULL PRIMARY KEY);"
+	if !assert.NoError(t, ParseSqlToFiles(sql, dir, WithTarget(TargetJSONSchema))) {
+		return
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "user_tags.json"))
+	if assert.NoError(t, err) {
+		assert.True(t, json.Valid(b))
+		assert.Contains(t, string(b), `"title": "UserTags"`)
+	}
+}
@@ -0,0 +1,300 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // keyword or bare identifier
+	tokenQuoted                  // quoted identifier, text is the unquoted name
+	tokenString                  // string literal, text is the decoded value
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	line int
+	pos  int // offset of the token in source
+	end  int // offset after the token in source
+}
+
+// is reports whether the token is a bare word matching one of words, case-insensitively.
+func (t token) is(words ...string) bool {
+	if t.kind != tokenWord {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) isSymbol(s string) bool {
+	return t.kind == tokenSymbol && t.text == s
+}
+
+// isName reports whether the token can be used as an identifier.
+func (t token) isName() bool {
+	return t.kind == tokenWord || t.kind == tokenQuoted
+}
+
+type lexerConfig struct {
+	identQuotes     string // characters opening a quoted identifier
+	backslashEscape bool   // backslash escapes characters in string literals
+	dollarQuote     bool   // postgres $tag$...$tag$ strings
+	hashComment     bool   // '#' starts a line comment
+	doubleQuoteStr  bool   // '"' quotes a string literal like MySQL
+	nationalStrings bool   // N'...' is a string literal
+	escapeStrings   bool   // backslash escapes characters in E'...' like postgres
+}
+
+func lex(sql string, cfg lexerConfig) ([]token, error) {
+	tokens := make([]token, 0, len(sql)/4)
+	line := 1
+	i := 0
+	for i < len(sql) {
+		c := sql[i]
+		start, count := i, len(tokens)
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case c == '-' && strings.HasPrefix(sql[i:], "--"), c == '#' && cfg.hashComment:
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, parseErrorf(line, "unterminated comment")
+			}
+			comment := sql[i : i+2+end+2]
+			line += strings.Count(comment, "\n")
+			i += len(comment)
+		case c == '\'' || c == '"' && cfg.doubleQuoteStr,
+			(c == 'N' || c == 'n') && cfg.nationalStrings && i+1 < len(sql) && sql[i+1] == '\'':
+			if c != '\'' && c != '"' {
+				i++
+			}
+			s, n, err := lexString(sql[i:], cfg.backslashEscape)
+			if err != nil {
+				return nil, parseErrorf(line, "%s", err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s, line: line})
+			line += strings.Count(sql[i:i+n], "\n")
+			i += n
+		case (c == 'E' || c == 'e') && cfg.escapeStrings && i+1 < len(sql) && sql[i+1] == '\'':
+			s, n, err := lexString(sql[i+1:], true)
+			if err != nil {
+				return nil, parseErrorf(line, "%s", err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s, line: line})
+			line += strings.Count(sql[i+1:i+1+n], "\n")
+			i += 1 + n
+		case strings.IndexByte(cfg.identQuotes, c) >= 0:
+			closeQuote := c
+			if c == '[' {
+				closeQuote = ']'
+			}
+			j := i + 1
+			name := strings.Builder{}
+			for {
+				if j >= len(sql) {
+					return nil, parseErrorf(line, "unterminated quoted identifier")
+				}
+				if sql[j] == closeQuote {
+					// a doubled closing quote stands for itself
+					if j+1 < len(sql) && sql[j+1] == closeQuote {
+						name.WriteByte(closeQuote)
+						j += 2
+						continue
+					}
+					break
+				}
+				name.WriteByte(sql[j])
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenQuoted, text: name.String(), line: line})
+			i = j + 1
+		case c == '$' && cfg.dollarQuote && isDollarTag(sql[i:]):
+			tag := sql[i : i+strings.IndexByte(sql[i+1:], '$')+2]
+			end := strings.Index(sql[i+len(tag):], tag)
+			if end < 0 {
+				return nil, parseErrorf(line, "unterminated dollar-quoted string")
+			}
+			body := sql[i+len(tag) : i+len(tag)+end]
+			tokens = append(tokens, token{kind: tokenString, text: body, line: line})
+			line += strings.Count(body, "\n")
+			i += len(tag)*2 + end
+		case isDigit(c) || c == '.' && i+1 < len(sql) && isDigit(sql[i+1]):
+			j := i
+			for j < len(sql) && (isDigit(sql[j]) || sql[j] == '.') {
+				j++
+			}
+			if j < len(sql) && (sql[j] == 'e' || sql[j] == 'E') {
+				k := j + 1
+				if k < len(sql) && (sql[k] == '+' || sql[k] == '-') {
+					k++
+				}
+				if k < len(sql) && isDigit(sql[k]) {
+					for j = k; j < len(sql) && isDigit(sql[j]); j++ {
+					}
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: sql[i:j], line: line})
+			i = j
+		case isWordByte(c):
+			j := i
+			for j < len(sql) && (isWordByte(sql[j]) || isDigit(sql[j]) || sql[j] == '$') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: sql[i:j], line: line})
+			i = j
+		default:
+			n := 1
+			if i+1 < len(sql) {
+				switch sql[i : i+2] {
+				case "::", "<=", ">=", "<>", "!=", "||":
+					n = 2
+				}
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: sql[i : i+n], line: line})
+			i += n
+		}
+		if len(tokens) > count {
+			tokens[count].pos, tokens[count].end = start, i
+		}
+	}
+	return tokens, nil
+}
+
+// lexString decodes the string literal at the start of s, returning the value
+// and the length of the literal.
+func lexString(s string, backslashEscape bool) (string, int, error) {
+	quote := s[0]
+	value := strings.Builder{}
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && backslashEscape && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				value.WriteByte('\n')
+			case 't':
+				value.WriteByte('\t')
+			case 'r':
+				value.WriteByte('\r')
+			case '0':
+				value.WriteByte(0)
+			default:
+				value.WriteByte(s[i])
+			}
+		case c == quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				value.WriteByte(quote)
+				i++
+				continue
+			}
+			return value.String(), i + 1, nil
+		default:
+			value.WriteByte(c)
+		}
+	}
+	return "", 0, errors.New("unterminated string")
+}
+
+func isDollarTag(s string) bool {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '$' {
+			return true
+		}
+		if !isWordByte(s[i]) && !isDigit(s[i]) {
+			return false
+		}
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || c >= 0x80
+}
+
+// splitStatements splits tokens into statements separated by semicolons,
+// empty statements are dropped.
+func splitStatements(tokens []token) [][]token {
+	stmts := make([][]token, 0, 1)
+	start := 0
+	for i, t := range tokens {
+		if t.isSymbol(";") {
+			if i > start {
+				stmts = append(stmts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		stmts = append(stmts, tokens[start:])
+	}
+	return stmts
+}
+
+// renderMysql writes tokens back as MySQL text. Line breaks of the source are
+// kept so that positions in parser errors still point at the right line.
+func renderMysql(tokens []token) string {
+	b := strings.Builder{}
+	line := 0
+	for i, t := range tokens {
+		if i == 0 {
+			line = t.line
+		} else if t.line > line {
+			b.WriteString(strings.Repeat("\n", t.line-line))
+			line = t.line
+		} else if needSpace(tokens[i-1], t) {
+			b.WriteByte(' ')
+		}
+		switch t.kind {
+		case tokenQuoted:
+			b.WriteString(quoteIdent(t.text))
+		case tokenString:
+			b.WriteString(quoteString(t.text))
+		default:
+			b.WriteString(t.text)
+		}
+	}
+	return b.String()
+}
+
+func needSpace(prev, t token) bool {
+	if prev.isSymbol("(") || prev.isSymbol(".") {
+		return false
+	}
+	if t.isSymbol("(") {
+		// keep "DEFAULT (1)" apart, it's not a call of DEFAULT()
+		return prev.kind != tokenWord && prev.kind != tokenQuoted || prev.is("DEFAULT", "AS", "CHECK", "AND", "OR", "NOT", "IN")
+	}
+	return !t.isSymbol(")") && !t.isSymbol(",") && !t.isSymbol(".") && !t.isSymbol(";")
+}
+
+func quoteIdent(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func quoteString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `''`, "\n", `\n`, "\r", `\r`, "\t", `\t`, "\x00", `\0`)
+	return "'" + r.Replace(s) + "'"
+}
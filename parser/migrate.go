@@ -0,0 +1,45 @@
+package parser
+
+import (
+	gotoken "go/token"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// autoMigrateFile is the file of AutoMigrate written by ParseSqlToFiles
+const autoMigrateFile = "auto_migrate.go"
+
+var autoMigrateTmpl = template.Must(template.New("autoMigrate").Parse(`
+// {{.Func}} migrates the tables of generated models
+func {{.Func}}(db *gorm.DB) error {
+	return db.AutoMigrate(
+{{- range .Models}}
+		&{{.}}{},
+{{- end}}
+	)
+}
+`))
+
+type tmplAutoMigrate struct {
+	Func   string
+	Models []string
+}
+
+// makeAutoMigrate makes the function of WithAutoMigrateFunc migrating models,
+// it's empty without a model or for a target other than Go models of gorm.
+func makeAutoMigrate(names []string, opt options) (string, error) {
+	if opt.AutoMigrateFunc == "" || opt.Target != TargetGo || opt.ORM != ORMGorm || len(names) == 0 {
+		return "", nil
+	}
+	if !gotoken.IsIdentifier(opt.AutoMigrateFunc) {
+		return "", errors.Errorf("invalid function name of auto migrate: %s", opt.AutoMigrateFunc)
+	}
+	builder := strings.Builder{}
+	data := tmplAutoMigrate{Func: opt.AutoMigrateFunc, Models: names}
+	if err := autoMigrateTmpl.Execute(&builder, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+)
+
+// gormModelColumns are the columns of gorm.Model
+var gormModelColumns = []string{"id", "created_at", "updated_at", "deleted_at"}
+
+// isGormModelColumn reports whether a column without column prefix is one of
+// gorm.Model
+func isGormModelColumn(name string) bool {
+	for _, c := range gormModelColumns {
+		if strings.EqualFold(name, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// canEmbedGormModel reports whether the table has all columns of gorm.Model
+// with compatible types: an integer primary key id, time columns created_at,
+// updated_at and nullable deleted_at.
+func canEmbedGormModel(table TableInfo, opt options) bool {
+	found := 0
+	for _, col := range table.Columns {
+		name := trimColumnPrefix(col.Name, opt)
+		if !isGormModelColumn(name) || matchColumn(name, opt.ExcludeColumns) {
+			continue
+		}
+		var ok bool
+		switch strings.ToLower(name) {
+		case "id":
+			switch col.tp.Tp {
+			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+				ok = col.PrimaryKey
+			}
+		case "created_at", "updated_at":
+			ok = col.tp.Tp == mysql.TypeDatetime || col.tp.Tp == mysql.TypeTimestamp
+		case "deleted_at":
+			ok = (col.tp.Tp == mysql.TypeDatetime || col.tp.Tp == mysql.TypeTimestamp) && !col.NotNull
+		}
+		if !ok {
+			return false
+		}
+		found++
+	}
+	return found == len(gormModelColumns)
+}
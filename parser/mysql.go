@@ -2,6 +2,8 @@ package parser
 
 import (
 	"database/sql"
+	"strings"
+
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/pkg/errors"
 )
@@ -12,6 +14,12 @@ func GetCreateTableFromDB(dsn, tableName string) (string, error) {
 		return "", errors.WithMessage(err, "open db error")
 	}
 	defer db.Close()
+	return GetCreateTableFromConn(db, tableName)
+}
+
+// GetCreateTableFromConn gets CREATE TABLE of a MySQL table by db opened by
+// the caller, db isn't closed.
+func GetCreateTableFromConn(db *sql.DB, tableName string) (string, error) {
 	rows, err := db.Query("SHOW CREATE TABLE " + tableName)
 	if err != nil {
 		return "", errors.WithMessage(err, "query show create table error")
@@ -29,6 +37,47 @@ func GetCreateTableFromDB(dsn, tableName string) (string, error) {
 	return createSql, nil
 }
 
+// GetAllCreateTablesFromDB gets CREATE TABLE of every base table in the
+// database of dsn, views are excluded.
+func GetAllCreateTablesFromDB(dsn string) ([]string, error) {
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, errors.WithMessage(err, "open db error")
+	}
+	defer db.Close()
+	return GetAllCreateTablesFromConn(db)
+}
+
+// GetAllCreateTablesFromConn is GetAllCreateTablesFromDB by db opened by the
+// caller, db isn't closed.
+func GetAllCreateTablesFromConn(db *sql.DB) ([]string, error) {
+	rows, err := db.Query("SHOW FULL TABLES WHERE Table_type = 'BASE TABLE'")
+	if err != nil {
+		return nil, errors.WithMessage(err, "query show tables error")
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name, tableType string
+		if err := rows.Scan(&name, &tableType); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	creates := make([]string, 0, len(names))
+	for _, name := range names {
+		createSql, err := GetCreateTableFromConn(db, "`"+strings.ReplaceAll(name, "`", "``")+"`")
+		if err != nil {
+			return nil, err
+		}
+		creates = append(creates, createSql)
+	}
+	return creates, nil
+}
+
 func ParseSqlFromDB(dsn, tableName string, options ...Option) (ModelCodes, error) {
 	createSql, err := GetCreateTableFromDB(dsn, tableName)
 	if err != nil {
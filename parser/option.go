@@ -1,5 +1,10 @@
 package parser
 
+import (
+	"strings"
+	"text/template"
+)
+
 type NullStyle int
 
 const (
@@ -8,32 +13,142 @@ const (
 	NullInPointer
 )
 
+// ORM decides which tag is written for columns
+type ORM int
+
+const (
+	ORMGorm ORM = iota
+	ORMXorm
+)
+
+// Target is the language of output
+type Target int
+
+const (
+	TargetGo Target = iota
+	TargetTypeScript
+	TargetEnt
+	TargetProto
+	TargetJSONSchema
+	TargetGraphQL
+)
+
+// JsonTagStyle decides how the name in json tag is made from column name
+type JsonTagStyle int
+
+const (
+	JsonKeepColumn JsonTagStyle = iota // column name without column prefix
+	JsonCamelCase                      // lower camel case, e.g. userId
+	JsonSnakeCase                      // snake case, e.g. user_id
+)
+
+// FieldOrder decides the order of fields in struct
+type FieldOrder int
+
+const (
+	OrderDeclaration     FieldOrder = iota // the order of columns in table
+	OrderAlphabetical                      // sorted by column name
+	OrderPrimaryKeyFirst                   // primary keys then the others in declaration order
+)
+
 type Option func(*options)
 
 type options struct {
-	Charset        string
-	Collation      string
-	JsonTag        bool
-	TablePrefix    string
-	ColumnPrefix   string
-	NoNullType     bool
-	NullStyle      NullStyle
-	Package        string
-	GormType       bool
-	ForceTableName bool
+	Charset                 string
+	Collation               string
+	JsonTag                 bool
+	TablePrefix             string
+	TableSuffix             string
+	ColumnPrefix            string
+	NoNullType              bool
+	NullStyle               NullStyle
+	Package                 string
+	GormType                bool
+	ForceTableName          bool
+	NoTableName             bool
+	SchemaInTableName       bool
+	TableNameConst          bool
+	Dialect                 Dialect
+	Associations            bool
+	IndexTags               bool
+	Comments                bool
+	CommentTags             bool
+	ORM                     ORM
+	SoftDelete              []string
+	GormTimestamps          bool
+	TypeMapping             map[string]string
+	UnsignedTypes           bool
+	DecimalType             string
+	EnumConstants           bool
+	ValidateTag             bool
+	ValidateDefaultRequired bool
+	Target                  Target
+	GraphQLFieldStyle       JsonTagStyle
+	DefaultTags             bool
+	SingularStruct          bool
+	UUIDType                string
+	UUIDColumns             []string
+	BsonTag                 bool
+	DBTag                   bool
+	JsonTagStyle            JsonTagStyle
+	JsonOmitEmpty           bool
+	Source                  string
+	TinyIntBool             bool
+	TimeAsDuration          bool
+	ExcludeColumns          []string
+	GormModel               bool
+	YamlTag                 bool
+	Annotations             bool
+	StructNames             map[string]string
+	FieldOrder              FieldOrder
+	AssociationPointers     bool
+	JSONDatatype            bool
+	NullAccessors           bool
+	UnexportedFields        bool
+	OmitRedundantColumnTag  bool
+	ExplicitColumnTags      bool
+	Repository              bool
+	ExternalPackage         string
+	TablePackages           map[string]string // lower case table names to import paths
+	SizeTag                 bool
+	StructNamePrefix        string
+	Template                string
+	FileHeader              []string
+	StringMethod            bool
+	Constructor             bool
+	AutoMigrateFunc         string
+	PrimaryKeyType          string
+	RedactedColumns         []string
+	NullColumns             []string
+	SpatialType             string
+	IncludeViews            bool
+	UnknownTypeFallback     string
+	EmbeddedGroups          []string
+	ColumnsHelper           bool
+	LineEnding              string
+	PqArrays                bool
+	PointersForDefaults     bool
+	JSONScanner             bool
+
+	structTmpl *template.Template // parsed Template
 }
 
 var defaultOptions = options{
-	NullStyle: NullInSql,
-	Package:   "model",
+	NullStyle:         NullInSql,
+	PrimaryKeyType:    "uint",
+	Package:           "model",
+	GraphQLFieldStyle: JsonCamelCase,
 }
 
+// WithCharset sets character set of string columns if neither the column nor
+// the table declares one, it's written in gorm tag with WithGormType.
 func WithCharset(charset string) Option {
 	return func(o *options) {
 		o.Charset = charset
 	}
 }
 
+// WithCollation sets collation of string columns like WithCharset
 func WithCollation(collation string) Option {
 	return func(o *options) {
 		o.Collation = collation
@@ -46,6 +161,14 @@ func WithTablePrefix(p string) Option {
 	}
 }
 
+// WithTableSuffix removes the suffix like "_tbl" from table names for structs,
+// TableName still returns the whole name.
+func WithTableSuffix(s string) Option {
+	return func(o *options) {
+		o.TableSuffix = s
+	}
+}
+
 func WithColumnPrefix(p string) Option {
 	return func(o *options) {
 		o.ColumnPrefix = p
@@ -58,6 +181,41 @@ func WithJsonTag() Option {
 	}
 }
 
+// WithJsonTagStyle sets the style of name in json tag, JsonKeepColumn by default
+func WithJsonTagStyle(style JsonTagStyle) Option {
+	return func(o *options) {
+		o.JsonTagStyle = style
+	}
+}
+
+// WithJsonOmitEmpty adds omitempty to json tag of nullable columns
+func WithJsonOmitEmpty() Option {
+	return func(o *options) {
+		o.JsonOmitEmpty = true
+	}
+}
+
+// WithYamlTag writes yaml tag with the same name of json tag
+func WithYamlTag() Option {
+	return func(o *options) {
+		o.YamlTag = true
+	}
+}
+
+// WithBsonTag writes bson tag with the same name of json tag
+func WithBsonTag() Option {
+	return func(o *options) {
+		o.BsonTag = true
+	}
+}
+
+// WithDBTag writes db tag of the column name for sqlx, e.g. db:"email"
+func WithDBTag() Option {
+	return func(o *options) {
+		o.DBTag = true
+	}
+}
+
 func WithNoNullType() Option {
 	return func(o *options) {
 		o.NoNullType = true
@@ -70,6 +228,15 @@ func WithNullStyle(s NullStyle) Option {
 	}
 }
 
+// WithPointersForDefaults makes NOT NULL columns with a default value pointers,
+// so that gorm inserts the default instead of the zero value when a field is
+// nil. Columns filled by WithGormTimestamps are not changed.
+func WithPointersForDefaults() Option {
+	return func(o *options) {
+		o.PointersForDefaults = true
+	}
+}
+
 func WithPackage(pkg string) Option {
 	return func(o *options) {
 		o.Package = pkg
@@ -89,6 +256,539 @@ func WithForceTableName() Option {
 	}
 }
 
+// WithSchemaInTableName keeps the schema of a qualified table name in the
+// TableName method, e.g. "shop.users" of CREATE TABLE shop.users. The schema
+// is dropped by default, it's in TableInfo.Schema either way.
+func WithSchemaInTableName() Option {
+	return func(o *options) {
+		o.SchemaInTableName = true
+	}
+}
+
+// WithNoTableName never writes the TableName method, the table of a struct is
+// named by gorm, e.g. UserInfo is user_infos. It wins over WithForceTableName
+// and a warning is returned for a table gorm names otherwise.
+func WithNoTableName() Option {
+	return func(o *options) {
+		o.NoTableName = true
+	}
+}
+
+// WithUnknownTypeFallback sets the go type of columns of unknown types, like an
+// enum type or a domain of postgres, e.g. interface{} or
+// encoding/json.RawMessage. They are string by default, a warning is returned
+// for such a column anyway.
+func WithUnknownTypeFallback(goType string) Option {
+	return func(o *options) {
+		o.UnknownTypeFallback = goType
+	}
+}
+
+// WithEmbeddedGroups factors columns of a prefix into an embedded struct of
+// gorm, e.g. prefix "address" makes address_street and address_city fields of
+// struct Address with tag embedded;embeddedPrefix:address_. Primary keys and
+// foreign keys are not grouped.
+func WithEmbeddedGroups(prefixes ...string) Option {
+	return func(o *options) {
+		o.EmbeddedGroups = append(o.EmbeddedGroups, prefixes...)
+	}
+}
+
+// WithIncludeViews makes read only structs of CREATE VIEW in MySQL, columns
+// are from the tables created before and there is no primary key. Views are
+// skipped by default.
+func WithIncludeViews() Option {
+	return func(o *options) {
+		o.IncludeViews = true
+	}
+}
+
+// WithTableNameConst declares a constant of table name for each struct, e.g.
+// const UserTableName = "users", for raw queries and joins.
+func WithTableNameConst() Option {
+	return func(o *options) {
+		o.TableNameConst = true
+	}
+}
+
+// WithDialect sets the SQL dialect of input, default is MySQL
+func WithDialect(d Dialect) Option {
+	return func(o *options) {
+		o.Dialect = d
+	}
+}
+
+// WithAssociations makes a belongs to field for every foreign key
+func WithAssociations() Option {
+	return func(o *options) {
+		o.Associations = true
+	}
+}
+
+// WithAssociationPointers makes fields of WithAssociations pointers, e.g.
+// *User, nil means the association is not loaded.
+func WithAssociationPointers() Option {
+	return func(o *options) {
+		o.AssociationPointers = true
+	}
+}
+
+// WithIndexTags writes index and uniqueIndex in gorm tag from KEY, INDEX and UNIQUE KEY
+func WithIndexTags() Option {
+	return func(o *options) {
+		o.IndexTags = true
+	}
+}
+
+// WithComments writes column comment above the field instead of the end of line
+func WithComments() Option {
+	return func(o *options) {
+		o.Comments = true
+	}
+}
+
+// WithCommentTags writes column comment in gorm tag
+func WithCommentTags() Option {
+	return func(o *options) {
+		o.CommentTags = true
+	}
+}
+
+// WithORM sets the ORM of tag, default is gorm
+func WithORM(orm ORM) Option {
+	return func(o *options) {
+		o.ORM = orm
+	}
+}
+
+// WithSoftDelete uses gorm.DeletedAt for the nullable time column of soft delete,
+// columns are matched by name without column prefix, default is deleted_at
+func WithSoftDelete(columns ...string) Option {
+	return func(o *options) {
+		if len(columns) == 0 {
+			columns = []string{"deleted_at"}
+		}
+		o.SoftDelete = columns
+	}
+}
+
+// WithGormTimestamps makes created_at and updated_at follow conventions of gorm:
+// time columns are time.Time, integer columns get autoCreateTime and autoUpdateTime.
+// Other columns of ON UPDATE CURRENT_TIMESTAMP get autoUpdateTime.
+func WithGormTimestamps() Option {
+	return func(o *options) {
+		o.GormTimestamps = true
+	}
+}
+
+// WithTypeMapping maps SQL types to Go types over the default mapping. A key is
+// the whole declared type like "decimal(18,2)" or the type name like "decimal",
+// "int unsigned". A value is a Go type with import path like
+// "github.com/shopspring/decimal.Decimal". A nullable column gets a pointer of
+// the type with NullInPointer style, the type is used as is for other styles.
+func WithTypeMapping(m map[string]string) Option {
+	return func(o *options) {
+		if o.TypeMapping == nil {
+			o.TypeMapping = make(map[string]string, len(m))
+		}
+		for k, v := range m {
+			o.TypeMapping[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// WithStructNamePrefix prepends prefix to struct names made from table names,
+// e.g. PGUser of table users. Names of WithStructNameMap are not changed.
+func WithStructNamePrefix(prefix string) Option {
+	return func(o *options) {
+		o.StructNamePrefix = prefix
+	}
+}
+
+// WithStructNameMap names structs of tables in m, e.g. "tbl_usr" to "User", it
+// takes precedence over WithTablePrefix and WithSingularStruct. Table names are
+// case insensitive.
+func WithStructNameMap(m map[string]string) Option {
+	return func(o *options) {
+		if o.StructNames == nil {
+			o.StructNames = make(map[string]string, len(m))
+		}
+		for k, v := range m {
+			o.StructNames[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// WithExternalPackage sets the import path of structs of tables referenced by
+// foreign keys but not in the input, association fields are like pkg.Order.
+func WithExternalPackage(importPath string) Option {
+	return func(o *options) {
+		o.ExternalPackage = importPath
+	}
+}
+
+// WithTablePackageMap sets import paths of structs of referenced tables in m,
+// e.g. "orders" to "example.com/shop/order". It takes precedence over
+// WithExternalPackage. Table names are case insensitive.
+func WithTablePackageMap(m map[string]string) Option {
+	return func(o *options) {
+		if o.TablePackages == nil {
+			o.TablePackages = make(map[string]string, len(m))
+		}
+		for k, v := range m {
+			o.TablePackages[strings.ToLower(k)] = v
+		}
+	}
+}
+
+// WithUnsignedTypes maps unsigned integer columns to unsigned Go types of the
+// same size, e.g. uint8 for tinyint unsigned and uint32 for int unsigned,
+// instead of uint. It doesn't apply to NullInSql style.
+func WithUnsignedTypes() Option {
+	return func(o *options) {
+		o.UnsignedTypes = true
+	}
+}
+
+// WithDecimalType sets the Go type of decimal and numeric columns, e.g.
+// "github.com/shopspring/decimal.Decimal", the import path is added with it.
+// WithTypeMapping takes precedence over it.
+func WithDecimalType(goType string) Option {
+	return func(o *options) {
+		o.DecimalType = goType
+	}
+}
+
+// WithEnumConstants declares a named string type with constants of the values
+// for each enum column, and uses it as the type of field.
+func WithEnumConstants() Option {
+	return func(o *options) {
+		o.EnumConstants = true
+	}
+}
+
+// WithValidateTag writes validate tag of github.com/go-playground/validator,
+// "required" for NOT NULL columns except primary keys and columns with default
+// value, "max" for the length of char and varchar columns. Numbers and bool
+// are never required, the validator rejects their zero values.
+func WithValidateTag() Option {
+	return func(o *options) {
+		o.ValidateTag = true
+	}
+}
+
+// WithValidateDefaultRequired makes NOT NULL columns with default value
+// required in validate tag as well.
+func WithValidateDefaultRequired() Option {
+	return func(o *options) {
+		o.ValidateDefaultRequired = true
+	}
+}
+
+// WithTarget sets the language of output, TargetTypeScript writes an interface
+// for each table instead of Go struct, TargetEnt writes a schema of ent and
+// TargetProto writes a message of proto3. TargetJSONSchema writes a JSON Schema
+// of object, they are put in components.schemas of OpenAPI by ParseSqlToWrite.
+// TargetGraphQL writes a type of GraphQL SDL, time columns are a DateTime
+// scalar.
+func WithTarget(t Target) Option {
+	return func(o *options) {
+		o.Target = t
+	}
+}
+
+// WithGraphQLFieldStyle sets how fields of TargetGraphQL are named from column
+// names like json tags, it's JsonCamelCase by default.
+func WithGraphQLFieldStyle(style JsonTagStyle) Option {
+	return func(o *options) {
+		o.GraphQLFieldStyle = style
+	}
+}
+
+// WithDefaultTags writes default values for gorm auto migration, string
+// defaults are quoted so that an empty string is kept. A string starting or
+// ending with a quote is dropped with a warning since gorm trims it. It's
+// enabled by WithGormType as well.
+func WithDefaultTags() Option {
+	return func(o *options) {
+		o.DefaultTags = true
+	}
+}
+
+// WithSingularStruct names the struct in singular, e.g. User for table users
+// and Person for table people. TableName func is written if gorm can't get
+// the table name from struct name.
+func WithSingularStruct() Option {
+	return func(o *options) {
+		o.SingularStruct = true
+	}
+}
+
+// WithUUIDType maps UUID columns to goType with import path, e.g.
+// "github.com/google/uuid.UUID". A UUID column is binary(16), char(36) or uuid
+// type of other dialects, if columns are given, its name must match one of the
+// patterns as well, e.g. "*_id" and "uuid". A nullable column gets a pointer
+// with NullInPointer style.
+func WithUUIDType(goType string, columns ...string) Option {
+	return func(o *options) {
+		o.UUIDType = goType
+		o.UUIDColumns = append(o.UUIDColumns, columns...)
+	}
+}
+
+// WithSource writes where the SQL comes from in the header of file, e.g. the
+// name of SQL file.
+func WithSource(source string) Option {
+	return func(o *options) {
+		o.Source = source
+	}
+}
+
+// WithTimeAsDuration maps time columns to time.Duration instead of string,
+// the driver must return them as nanoseconds or a scanner is needed.
+func WithTimeAsDuration() Option {
+	return func(o *options) {
+		o.TimeAsDuration = true
+	}
+}
+
+// WithTinyIntBool maps tinyint(1) columns to bool, tinyint columns of other
+// display width or without it are still integers. Columns declared bool or
+// boolean are bool without it.
+func WithTinyIntBool() Option {
+	return func(o *options) {
+		o.TinyIntBool = true
+	}
+}
+
+// WithPrimaryKeyType sets the Go type of auto increment primary keys whatever
+// the width of column, it's uint by default like gorm.Model. An empty goType
+// keeps the unsigned type of the column, e.g. uint64 of bigint with
+// WithUnsignedTypes. Foreign key columns referencing such a key get its type,
+// other columns are mapped as usual.
+func WithPrimaryKeyType(goType string) Option {
+	return func(o *options) {
+		o.PrimaryKeyType = goType
+	}
+}
+
+// WithExcludeColumns drops columns from struct, a pattern is a column name
+// without column prefix or a glob like "etl_*", case insensitive.
+func WithExcludeColumns(patterns ...string) Option {
+	return func(o *options) {
+		o.ExcludeColumns = append(o.ExcludeColumns, patterns...)
+	}
+}
+
+// WithGormModel embeds gorm.Model instead of columns id, created_at, updated_at
+// and deleted_at if the table has all of them with compatible types.
+func WithGormModel() Option {
+	return func(o *options) {
+		o.GormModel = true
+	}
+}
+
+// WithAnnotations reads directives in column comment, they are removed from
+// the comment:
+//
+//	@gotype:github.com/me/types.Email  Go type with import path
+//	@json:emailAddr                     name in json tag, "-" to ignore it
+//	@gorm:column:email;unique           the whole gorm tag
+//	@skip                               skip the column
+func WithAnnotations() Option {
+	return func(o *options) {
+		o.Annotations = true
+	}
+}
+
+// WithFieldOrder sets the order of fields, OrderDeclaration by default
+func WithFieldOrder(order FieldOrder) Option {
+	return func(o *options) {
+		o.FieldOrder = order
+	}
+}
+
+// WithJSONDatatype maps json columns to datatypes.JSON of gorm.io/datatypes
+// instead of string. WithTypeMapping takes precedence over it.
+func WithJSONDatatype() Option {
+	return func(o *options) {
+		o.JSONDatatype = true
+	}
+}
+
+// WithNullAccessors writes methods GetXXX and SetXXX for fields of
+// sql.NullXXX and pointer, e.g. GetEmail() (string, bool).
+func WithNullAccessors() Option {
+	return func(o *options) {
+		o.NullAccessors = true
+	}
+}
+
+// WithUnexportedFields lowers the first letter of field names, e.g. userID.
+// Gorm and encoding/json ignore unexported fields, it suits code reading the
+// fields in the same package, such as WithNullAccessors.
+func WithUnexportedFields() Option {
+	return func(o *options) {
+		o.UnexportedFields = true
+	}
+}
+
+// WithOmitRedundantColumnTag omits column of gorm tag if the default naming
+// strategy of gorm gets the same name from the field, e.g. user_id of UserID.
+func WithOmitRedundantColumnTag() Option {
+	return func(o *options) {
+		o.OmitRedundantColumnTag = true
+	}
+}
+
+// WithExplicitColumnTags writes column of gorm tag for every field, which is
+// the default. It wins over WithOmitRedundantColumnTag, the tags don't change
+// with a NamingStrategy of gorm.
+func WithExplicitColumnTags() Option {
+	return func(o *options) {
+		o.ExplicitColumnTags = true
+	}
+}
+
+// WithRepository writes a repository interface of Create, FindByID, Update and
+// Delete after each struct and its implementation by gorm. Tables without
+// primary key have no repository.
+func WithRepository() Option {
+	return func(o *options) {
+		o.Repository = true
+	}
+}
+
+// WithSizeTag writes size of char and varchar in gorm tag, e.g. size:255, and
+// type of text and blob, e.g. type:longtext. It's ignored with WithGormType.
+func WithSizeTag() Option {
+	return func(o *options) {
+		o.SizeTag = true
+	}
+}
+
+// WithConstructor writes a constructor of each struct like NewUser, fields
+// are set to the default values of columns, e.g. Status: 1. Defaults of
+// functions and expressions, zero values, auto increment and auto time fields
+// and fields of embedded structs are left out.
+func WithConstructor() Option {
+	return func(o *options) {
+		o.Constructor = true
+	}
+}
+
+// WithAutoMigrateFunc writes a function like AutoMigrate(db *gorm.DB) error
+// at the end of the file migrating all generated models, funcName is
+// AutoMigrate if empty. It's only for Go models of gorm, names of the models
+// are in TypeNames of ModelCodes either way.
+func WithAutoMigrateFunc(funcName string) Option {
+	return func(o *options) {
+		if funcName == "" {
+			funcName = "AutoMigrate"
+		}
+		o.AutoMigrateFunc = funcName
+	}
+}
+
+// WithStringMethod writes String() of each struct for logging, columns of
+// WithRedactedColumns are written as <redacted>.
+func WithStringMethod() Option {
+	return func(o *options) {
+		o.StringMethod = true
+	}
+}
+
+// WithColumnsHelper writes a variable like UserColumns of column names by
+// field names and Columns() of each struct, so a query can be built without
+// reflect.
+func WithColumnsHelper() Option {
+	return func(o *options) {
+		o.ColumnsHelper = true
+	}
+}
+
+// WithSpatialType sets the go type of spatial columns like point and geometry
+// of MySQL and PostGIS, e.g. github.com/twpayne/go-geom/encoding/ewkb.Point.
+// They are []byte of WKB by default, the type must scan the value.
+func WithSpatialType(goType string) Option {
+	return func(o *options) {
+		o.SpatialType = goType
+	}
+}
+
+// WithNullColumns makes the fields of columns null types of the null style
+// whatever the DDL says, e.g. sql.NullString of a NOT NULL description, and
+// sql.NullXXX with WithNoNullType. A pattern is matched like
+// WithExcludeColumns, primary keys are kept.
+func WithNullColumns(patterns ...string) Option {
+	return func(o *options) {
+		o.NullColumns = append(o.NullColumns, patterns...)
+	}
+}
+
+// WithRedactedColumns hides values of columns like password in String() of
+// WithStringMethod, a pattern is a column name or a glob like WithExcludeColumns.
+func WithRedactedColumns(patterns ...string) Option {
+	return func(o *options) {
+		o.RedactedColumns = append(o.RedactedColumns, patterns...)
+	}
+}
+
+// WithTemplate renders the code of each table by tmpl of text/template instead
+// of DefaultTemplate, the code is formatted and imports of field types are
+// written. Data of the template has these fields:
+//
+//	Table        TableInfo  the parsed table
+//	TableName    string     name of struct
+//	RawTableName string     name of table, with schema by WithSchemaInTableName
+//	NameFunc     bool       TableName method is needed
+//	NameConst    bool       constant of table name is declared
+//	Comment      []string   lines of table comment
+//	Fields       []struct{ Name, GoType, Tag, Comment string; Doc []string }
+//	Enums        []struct{ Name string; Values []struct{ Name, Value string } }
+//	Embedded     []struct{ Name string; Fields }  structs of WithEmbeddedGroups
+//	Accessors    []struct{ Getter, Setter, Field, Type, Value, Null string }
+func WithTemplate(tmpl string) Option {
+	return func(o *options) {
+		o.Template = tmpl
+	}
+}
+
+// WithFileHeader writes lines like //go:build mysql after the generated marker
+// of file and before the package clause.
+func WithFileHeader(lines ...string) Option {
+	return func(o *options) {
+		o.FileHeader = append(o.FileHeader, lines...)
+	}
+}
+
+// WithJSONScanner writes Scan and Value of the type of a JSON column, which is
+// a type of the package set by WithAnnotations or WithTypeMapping, e.g.
+// Profile. The value is marshaled to JSON and the type itself isn't declared.
+func WithJSONScanner() Option {
+	return func(o *options) {
+		o.JSONScanner = true
+	}
+}
+
+// WithPqArrays maps one-dimensional arrays of postgres to github.com/lib/pq
+// types like pq.StringArray and pq.Int64Array, which can be scanned. Arrays
+// are plain slices with type in gorm tag by default.
+func WithPqArrays() Option {
+	return func(o *options) {
+		o.PqArrays = true
+	}
+}
+
+// WithLineEnding sets the line ending of files written by ParseSqlToWrite and
+// ParseSqlToFiles, e.g. "\r\n" for Windows. It's "\n" by default, line
+// endings of the input are replaced either way.
+func WithLineEnding(ending string) Option {
+	return func(o *options) {
+		o.LineEnding = ending
+	}
+}
+
 func parseOption(options []Option) options {
 	o := defaultOptions
 	for _, f := range options {
@@ -1,10 +1,17 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"go/format"
+	gotoken "go/token"
 	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
@@ -13,7 +20,7 @@ import (
 	"github.com/knocknote/vitess-sqlparser/tidbparser/ast"
 	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
 	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/types"
-	"github.com/knocknote/vitess-sqlparser/tidbparser/parser"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/parser/opcode"
 	"github.com/pkg/errors"
 )
 
@@ -25,6 +32,20 @@ var (
 	tmplParseOnce sync.Once
 )
 
+// fileHeaderTmpl marks the file as generated, linters skip it by the marker.
+// Lines of WithFileHeader follow it, a blank line keeps build constraints
+// apart from the package clause.
+const fileHeaderTmpl = `// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+{{- if .Source}}
+// source: {{.Source}}
+{{- end}}
+{{- if .Header}}
+{{range .Header}}
+{{.}}
+{{- end}}
+{{- end}}
+`
+
 var acronym = map[string]struct{}{
 	"ID":  {},
 	"IP":  {},
@@ -33,56 +54,253 @@ var acronym = map[string]struct{}{
 
 type ModelCodes struct {
 	Package    string
+	Source     string   // where the SQL comes from, written in file header
+	Header     []string // lines after the generated marker, e.g. //go:build mysql
 	ImportPath []string
 	StructCode []string
+	TypeNames  []string  // names of generated types in the order of tables
+	Warnings   []Warning // what is dropped or guessed in the SQL
 }
 
+// ParseSql generates code of tables in sql, warnings are returned in the result
+// instead of being logged.
 func ParseSql(sql string, options ...Option) (ModelCodes, error) {
-	initTemplate()
 	opt := parseOption(options)
-
-	stmts, err := parser.New().Parse(sql, opt.Charset, opt.Collation)
+	tables, warnings, err := parseTables(sql, opt)
 	if err != nil {
 		return ModelCodes{}, err
 	}
-	tableStr := make([]string, 0, len(stmts))
-	importPath := make(map[string]struct{})
-	for _, stmt := range stmts {
-		if ct, ok := stmt.(*ast.CreateTableStmt); ok {
-			s, ipt, err := makeCode(ct, opt)
-			if err != nil {
-				return ModelCodes{}, err
-			}
-			tableStr = append(tableStr, s)
-			for _, s := range ipt {
-				importPath[s] = struct{}{}
-			}
-		}
+	tableStr := make([]string, 0, len(tables))
+	importPath := make([]string, 0, len(tables))
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		tableStr = append(tableStr, t.Code)
+		importPath = append(importPath, t.ImportPath...)
+		names = append(names, t.TypeName)
 	}
-	importPathArr := make([]string, 0, len(importPath))
-	for s := range importPath {
-		importPathArr = append(importPathArr, s)
+	migrate, err := makeAutoMigrate(names, opt)
+	if err != nil {
+		return ModelCodes{}, err
+	}
+	if migrate != "" {
+		tableStr = append(tableStr, migrate)
+		importPath = append(importPath, "gorm.io/gorm")
 	}
-	sort.Strings(importPathArr)
 	return ModelCodes{
 		Package:    opt.Package,
-		ImportPath: importPathArr,
+		Source:     opt.Source,
+		Header:     opt.FileHeader,
+		ImportPath: sortImports(importPath),
 		StructCode: tableStr,
+		TypeNames:  names,
+		Warnings:   warnings,
 	}, nil
 }
 
 func ParseSqlToWrite(sql string, writer io.Writer, options ...Option) error {
+	opt := parseOption(options)
+	buf := bytes.Buffer{}
+	if opt.Target == TargetJSONSchema {
+		tables, warnings, err := parseTables(sql, opt)
+		if err != nil {
+			return err
+		}
+		logWarnings(warnings)
+		if err := writeJSONSchemas(&buf, tables, opt); err != nil {
+			return err
+		}
+		_, err = writer.Write(withLineEnding(buf.Bytes(), opt.LineEnding))
+		return err
+	}
 	data, err := ParseSql(sql, options...)
 	if err != nil {
 		return err
 	}
-	err = fileTmpl.Execute(writer, data)
+	logWarnings(data.Warnings)
+	if tmpl, _ := textFileTmpl(opt.Target); tmpl != nil {
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+		_, err = writer.Write(withLineEnding(buf.Bytes(), opt.LineEnding))
+		return err
+	}
+	err = fileTmpl.Execute(&buf, data)
 	if err != nil {
 		return err
 	}
+	code, fmtErr := formatCode(buf.Bytes())
+	if _, err := writer.Write(withLineEnding(code, opt.LineEnding)); err != nil {
+		return err
+	}
+	return fmtErr
+}
+
+// ParseSqlToFiles writes a file for each table in dir, the file is named after
+// the table name in snake case, with extension .ts for TargetTypeScript and
+// .proto for TargetProto, .json for TargetJSONSchema and .graphql for
+// TargetGraphQL. dir is created if missing, and existing files are overwritten.
+// The function of WithAutoMigrateFunc is written in auto_migrate.go.
+func ParseSqlToFiles(sql string, dir string, options ...Option) error {
+	opt := parseOption(options)
+	tables, warnings, err := parseTables(sql, opt)
+	if err != nil {
+		return err
+	}
+	logWarnings(warnings)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(tables))
+	for _, t := range tables {
+		names = append(names, t.TypeName)
+		data := ModelCodes{
+			Package:    opt.Package,
+			Source:     opt.Source,
+			Header:     opt.FileHeader,
+			ImportPath: sortImports(t.ImportPath),
+			StructCode: []string{t.Code},
+		}
+		buf := bytes.Buffer{}
+		if tmpl, ext := textFileTmpl(opt.Target); tmpl != nil {
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return err
+			}
+			file := filepath.Join(dir, toSnake(t.Name)+ext)
+			if err := ioutil.WriteFile(file, withLineEnding(buf.Bytes(), opt.LineEnding), 0666); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fileTmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+		code, fmtErr := formatCode(buf.Bytes())
+		file := filepath.Join(dir, toSnake(t.Name)+".go")
+		if err := ioutil.WriteFile(file, withLineEnding(code, opt.LineEnding), 0666); err != nil {
+			return err
+		}
+		if fmtErr != nil {
+			return errors.WithMessage(fmtErr, file)
+		}
+	}
+	migrate, err := makeAutoMigrate(names, opt)
+	if err != nil || migrate == "" {
+		return err
+	}
+	data := ModelCodes{
+		Package:    opt.Package,
+		Source:     opt.Source,
+		Header:     opt.FileHeader,
+		ImportPath: []string{"gorm.io/gorm"},
+		StructCode: []string{migrate},
+	}
+	buf := bytes.Buffer{}
+	if err := fileTmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	code, fmtErr := formatCode(buf.Bytes())
+	file := filepath.Join(dir, autoMigrateFile)
+	if err := ioutil.WriteFile(file, withLineEnding(code, opt.LineEnding), 0666); err != nil {
+		return err
+	}
+	if fmtErr != nil {
+		return errors.WithMessage(fmtErr, file)
+	}
 	return nil
 }
 
+// textFileTmpl gets the file template and extension of a target other than Go,
+// the code isn't formatted. It's nil for Go.
+func textFileTmpl(target Target) (*template.Template, string) {
+	switch target {
+	case TargetTypeScript:
+		return tsFileTmpl, ".ts"
+	case TargetProto:
+		return protoFileTmpl, ".proto"
+	case TargetJSONSchema:
+		return jsonSchemaFileTmpl, ".json"
+	case TargetGraphQL:
+		return graphQLFileTmpl, ".graphql"
+	}
+	return nil, ""
+}
+
+// tableCode is the code generated for a table
+type tableCode struct {
+	Name       string
+	TypeName   string // name of the struct or type
+	Code       string
+	ImportPath []string
+}
+
+func parseTables(sql string, opt options) ([]tableCode, []Warning, error) {
+	initTemplate()
+	if opt.Template != "" {
+		tmpl, err := template.New("custom").Parse(opt.Template)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "parse template error")
+		}
+		opt.structTmpl = tmpl
+	}
+
+	tables, ctx, err := parseTableInfos(sql, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	codes := make([]tableCode, 0, len(tables))
+	for _, t := range tables {
+		t.Columns = sortColumns(t.Columns, opt)
+		var s string
+		var ipt []string
+		switch opt.Target {
+		case TargetTypeScript:
+			s, err = makeTypeScript(t, opt)
+		case TargetEnt:
+			s, ipt, err = makeEnt(t, opt)
+		case TargetProto:
+			s, ipt, err = makeProto(t, opt)
+		case TargetJSONSchema:
+			s, err = makeJSONSchema(t, opt)
+		case TargetGraphQL:
+			s, ipt, err = makeGraphQL(t, opt)
+		default:
+			s, ipt, err = makeCode(t, ctx, opt)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		codes = append(codes, tableCode{
+			Name:       t.Name,
+			TypeName:   structName(t.Name, opt),
+			Code:       s,
+			ImportPath: ipt,
+		})
+	}
+	return codes, ctx.warnings, nil
+}
+
+// generatedDoc describes a generated column, e.g. GENERATED AS (a + b) STORED
+func generatedDoc(col ColumnInfo) string {
+	if col.Stored {
+		return "GENERATED AS (" + col.Generated + ") STORED"
+	}
+	return "GENERATED AS (" + col.Generated + ") VIRTUAL"
+}
+
+// sortImports sorts import paths and removes duplicates
+func sortImports(paths []string) []string {
+	set := make(map[string]struct{}, len(paths))
+	sorted := make([]string, 0, len(paths))
+	for _, s := range paths {
+		if _, ok := set[s]; !ok {
+			set[s] = struct{}{}
+			sorted = append(sorted, s)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
 func ConfigureAcronym(words []string) {
 	acronym = make(map[string]struct{}, len(words))
 	for _, w := range words {
@@ -90,139 +308,500 @@ func ConfigureAcronym(words []string) {
 	}
 }
 
+// parseContext holds what is known about all tables of the input.
+type parseContext struct {
+	tables   map[string]struct{} // lower case names of tables in the input
+	enums    map[string]string   // declared enum types to their values
+	embedded map[string]string   // declared embedded structs to their fields
+	scanners map[string]struct{} // types with Scan and Value of WithJSONScanner
+	setType  bool                // StringSet of SET columns is declared
+	warnings []Warning
+}
+
+func (ctx *parseContext) warn(table, column, format string, args ...interface{}) {
+	ctx.warnings = append(ctx.warnings, newWarning(table, column, format, args...))
+}
+
 type tmplData struct {
+	Table        TableInfo
 	TableName    string
 	NameFunc     bool
+	NameConst    bool // declare <TableName>TableName
 	RawTableName string
 	Fields       []tmplField
-	Comment      string
+	Comment      []string
+	Enums        []tmplEnum
+	Embedded     []tmplEmbedded
+	Accessors    []tmplAccessor
 }
 
 type tmplField struct {
 	Name    string
 	GoType  string
 	Tag     string
-	Comment string
+	Comment string   // comment at the end of line
+	Doc     []string // comment lines above the field
 }
 
-func makeCode(stmt *ast.CreateTableStmt, opt options) (string, []string, error) {
+func makeCode(table TableInfo, ctx *parseContext, opt options) (string, []string, error) {
 	importPath := make([]string, 0, 1)
 	data := tmplData{
-		TableName:    stmt.Table.Name.String(),
-		RawTableName: stmt.Table.Name.String(),
+		Table:        table,
+		TableName:    structName(table.Name, opt),
+		RawTableName: table.Name,
 		Fields:       make([]tmplField, 0, 1),
+		Comment:      commentLines(table.Comment),
+		NameConst:    opt.TableNameConst,
+	}
+	if opt.SchemaInTableName && table.Schema != "" {
+		data.RawTableName = table.Schema + "." + table.Name
 	}
-	tablePrefix := opt.TablePrefix
-	if tablePrefix != "" && strings.HasPrefix(data.TableName, tablePrefix) {
+	if trimTableName(table.Name, opt) != table.Name {
 		data.NameFunc = true
-		data.TableName = data.TableName[len(tablePrefix):]
 	}
-	if opt.ForceTableName || data.RawTableName != inflection.Plural(data.RawTableName) {
+	// gorm names the table of struct in plural snake case
+	gormTable := inflection.Plural(toSnake(data.TableName))
+	if opt.ForceTableName || data.RawTableName != gormTable {
 		data.NameFunc = true
 	}
-
-	data.TableName = toCamel(data.TableName)
-
-	// find table comment
-	for _, opt := range stmt.Options {
-		if opt.Tp == ast.TableOptionComment {
-			data.Comment = opt.StrValue
-			break
+	if opt.NoTableName {
+		if data.RawTableName != gormTable {
+			ctx.warn(table.Name, "", "TableName method is skipped, gorm takes %s as the table of %s", gormTable, data.TableName)
 		}
+		data.NameFunc = false
 	}
 
-	isPrimaryKey := make(map[string]bool)
-	for _, con := range stmt.Constraints {
-		if con.Tp == ast.ConstraintPrimaryKey {
-			isPrimaryKey[con.Keys[0].Column.String()] = true
+	var indexes map[string][]columnIndex
+	if opt.IndexTags {
+		indexes = getIndexes(table, opt)
+	}
+
+	// a check of single column is written above its field, others above the
+	// struct. Gorm tag of a check is on the first column.
+	docChecks := make(map[string][]string)
+	tagChecks := make(map[string][]string)
+	for _, check := range table.Checks {
+		if len(check.Columns) == 1 {
+			docChecks[check.Columns[0]] = append(docChecks[check.Columns[0]], "CHECK: "+check.Expr)
+		} else {
+			data.Comment = append(data.Comment, "CHECK: "+check.Expr)
+		}
+		if len(check.Columns) > 0 {
+			tagChecks[check.Columns[0]] = append(tagChecks[check.Columns[0]], gormCheck(check))
 		}
 	}
 
-	columnPrefix := opt.ColumnPrefix
-	for _, col := range stmt.Cols {
-		colName := col.Name.Name.String()
-		goFieldName := colName
-		if columnPrefix != "" && strings.HasPrefix(goFieldName, columnPrefix) {
-			goFieldName = goFieldName[len(columnPrefix):]
+	fieldNames := make(map[string]string, len(table.Columns))
+	var keys []tmplRepositoryKey
+	var stringFields []tmplStringField
+	var columns []tmplColumn
+	var scanners []string
+	declareSet := false
+	var defaults []tmplDefaultField
+	embedModel := opt.GormModel && opt.ORM == ORMGorm && canEmbedGormModel(table, opt)
+	modelEmbedded := false
+	groups := make(map[string]*embeddedGroup)
+	groupList := make([]*embeddedGroup, 0)
+	for i, col := range table.Columns {
+		colName := col.Name
+		hint := col.hint
+		goFieldName := trimColumnPrefix(colName, opt)
+		if matchColumn(goFieldName, opt.ExcludeColumns) {
+			if col.PrimaryKey {
+				ctx.warn(table.Name, colName, "the primary key is excluded")
+			}
+			continue
+		}
+		if embedModel && isGormModelColumn(goFieldName) {
+			// fields are promoted from gorm.Model
+			fieldNames[colName] = toCamel(goFieldName)
+			columns = append(columns, tmplColumn{Field: toCamel(goFieldName), Name: colName})
+			if col.PrimaryKey {
+				keys = append(keys, newRepositoryKey(colName, "ID", "uint"))
+			}
+			if opt.StringMethod {
+				name, goType := toCamel(goFieldName), "time.Time"
+				switch name {
+				case "ID":
+					goType = "uint"
+				case "DeletedAt":
+					goType = "gorm.DeletedAt"
+				}
+				stringFields = append(stringFields, newStringField(name, goType, matchColumn(goFieldName, opt.RedactedColumns)))
+			}
+			if !modelEmbedded {
+				data.Fields = append(data.Fields, tmplField{Name: "gorm.Model"})
+				importPath = append(importPath, "gorm.io/gorm")
+				modelEmbedded = true
+			}
+			continue
+		}
+		redacted := matchColumn(goFieldName, opt.RedactedColumns)
+		forceNull := !col.PrimaryKey && matchColumn(goFieldName, opt.NullColumns)
+		// a key stays in the model, gorm can't find it in an embedded struct
+		var group *embeddedGroup
+		if opt.ORM == ORMGorm && !col.PrimaryKey && !isForeignKeyColumn(table, colName) {
+			if prefix, rest := findEmbeddedPrefix(goFieldName, opt); rest != "" {
+				group = groups[strings.ToLower(prefix)]
+				if group == nil {
+					group = &embeddedGroup{Prefix: colName[:len(colName)-len(rest)], Index: len(data.Fields)}
+					groups[strings.ToLower(prefix)] = group
+					groupList = append(groupList, group)
+					data.Fields = append(data.Fields, newEmbeddedField(prefix, group.Prefix, opt))
+				}
+				goFieldName = rest
+			}
 		}
 
+		exportedName := toCamel(goFieldName)
+		if exportedName == "" {
+			// characters of the name are all dropped, e.g. a non-ASCII name
+			exportedName = "Column" + strconv.Itoa(i+1)
+		} else if isDigit(exportedName[0]) {
+			exportedName = "Column" + exportedName
+		}
 		field := tmplField{
-			Name: toCamel(goFieldName),
+			Name: exportedName,
+		}
+		if opt.UnexportedFields {
+			field.Name = unexportedName(exportedName)
+		}
+		// gorm adds embeddedPrefix to the column name of an embedded field
+		columnName := colName
+		if group != nil {
+			columnName = colName[len(group.Prefix):]
+			columns = append(columns, tmplColumn{Field: data.Fields[group.Index].GoType + exportedName, Name: colName})
+		} else {
+			fieldNames[colName] = field.Name
+			columns = append(columns, tmplColumn{Field: exportedName, Name: colName})
 		}
 
-		tags := make([]string, 0, 4)
-		// make GORM's tag
-		gormTag := strings.Builder{}
-		gormTag.WriteString("column:")
-		gormTag.WriteString(colName)
+		meta := columnMeta{
+			Name:            columnName,
+			Field:           field.Name,
+			Type:            col.Type,
+			PrimaryKey:      col.PrimaryKey,
+			AutoIncrement:   col.AutoIncrement,
+			ReadOnly:        col.Generated != "" || table.View,
+			NotNull:         col.NotNull,
+			CanNull:         col.nullDeclared,
+			HasDefault:      col.HasDefault,
+			Default:         col.Default,
+			DefaultIsString: col.DefaultIsString,
+			StringType:      col.tp.EvalType() == types.ETString,
+			Array:           hint.ArrayDims > 0,
+			Unique:          col.Unique,
+			Comment:         strings.Join(commentLines(col.Comment), " "),
+			Indexes:         indexes[colName],
+		}
 		if opt.GormType {
-			gormTag.WriteString(";type:")
-			gormTag.WriteString(col.Tp.InfoSchemaStr())
-		}
-		if isPrimaryKey[colName] {
-			gormTag.WriteString(";primary_key")
-		}
-		isNotNull := false
-		canNull := false
-		for _, o := range col.Options {
-			switch o.Tp {
-			case ast.ColumnOptionPrimaryKey:
-				if !isPrimaryKey[colName] {
-					gormTag.WriteString(";primary_key")
-					isPrimaryKey[colName] = true
-				}
-			case ast.ColumnOptionNotNull:
-				isNotNull = true
-			case ast.ColumnOptionAutoIncrement:
-				gormTag.WriteString(";AUTO_INCREMENT")
-			case ast.ColumnOptionDefaultValue:
-				if value := getDefaultValue(o.Expr); value != "" {
-					gormTag.WriteString(";default:")
-					gormTag.WriteString(value)
-				}
-			case ast.ColumnOptionUniqKey:
-				gormTag.WriteString(";unique")
-			case ast.ColumnOptionNull:
-				// gormTag.WriteString(";NULL")
-				canNull = true
-			case ast.ColumnOptionOnUpdate: // For Timestamp and Datetime only.
-			case ast.ColumnOptionFulltext:
-			case ast.ColumnOptionComment:
-				field.Comment = o.Expr.GetDatum().GetString()
-			default:
-				// return "", nil, errors.Errorf(" unsupport option %d\n", o.Tp)
+			meta.Checks = tagChecks[colName]
+			meta.Charset, meta.Collation = columnCharset(table, col, opt)
+		}
+		if isCharType(col.tp.Tp) && col.tp.Flen > 0 {
+			meta.Size = col.tp.Flen
+		} else if isTextType(col.tp.Tp) {
+			meta.TextType = types.TypeToStr(col.tp.Tp, col.tp.Charset)
+		}
+		if opt.Comments {
+			field.Doc = commentLines(col.Comment)
+		} else {
+			field.Comment = meta.Comment
+		}
+		field.Doc = append(field.Doc, docChecks[colName]...)
+		if col.Generated != "" {
+			field.Doc = append(field.Doc, generatedDoc(col))
+		}
+		if opt.ORM == ORMGorm && meta.StringType && meta.DefaultIsString && quotedAtEnds(meta.Default) {
+			// gorm trims quotes of a string default, they can't be escaped
+			ctx.warn(table.Name, colName, "default value %q is dropped from gorm tag, its quotes at the ends are trimmed by gorm", meta.Default)
+			meta.HasDefault, meta.Default = false, ""
+		}
+		canNull := meta.CanNull
+		meta.SoftDelete = isSoftDeleteColumn(goFieldName, col.tp, meta, opt)
+		if opt.GormTimestamps {
+			meta.AutoTime, meta.UnixTime = getAutoTime(goFieldName, col)
+			if meta.AutoTime != "" {
+				// gorm fills it, default value is meaningless
+				meta.HasDefault = false
+				meta.Default = ""
+				canNull = false
 			}
 		}
-		if !isPrimaryKey[colName] && isNotNull {
-			gormTag.WriteString(";NOT NULL")
+		if forceNull {
+			canNull = true
 		}
-		tags = append(tags, "gorm", gormTag.String())
 
-		if opt.JsonTag {
-			tags = append(tags, "json", goFieldName)
+		tags := make([]string, 0, 4)
+		switch {
+		case opt.ORM == ORMXorm:
+			tags = append(tags, "xorm", makeXormTag(meta, opt))
+		case col.ann.Gorm != "":
+			tags = append(tags, "gorm", col.ann.Gorm)
+		default:
+			if tag := makeGormTag(meta, opt); tag != "" {
+				tags = append(tags, "gorm", tag)
+			}
 		}
 
-		field.Tag = makeTagStr(tags)
+		name := jsonName(goFieldName, opt)
+		if col.ann.Json != "" {
+			name = col.ann.Json
+		}
+		if opt.JsonTag {
+			if opt.JsonOmitEmpty && canNull {
+				tags = append(tags, "json", name+",omitempty")
+			} else {
+				tags = append(tags, "json", name)
+			}
+		}
+		if opt.YamlTag {
+			tags = append(tags, "yaml", name)
+		}
+		if opt.BsonTag {
+			tags = append(tags, "bson", name)
+		}
+		if opt.DBTag {
+			tags = append(tags, "db", colName)
+		}
 
 		// get type in golang
 		nullStyle := opt.NullStyle
 		if !canNull {
 			nullStyle = NullDisable
+		} else if forceNull && nullStyle == NullDisable {
+			nullStyle = NullInSql
+		}
+		if opt.PointersForDefaults && !canNull && meta.HasDefault && !col.PrimaryKey {
+			// gorm inserts the default value for a nil pointer instead of zero
+			nullStyle = NullInPointer
+		}
+		intTp := autoIncrementType(col)
+		goType, pkg := mysqlToGoType(intTp, nullStyle)
+		if goType == "UnSupport" {
+			ctx.warn(table.Name, colName, "type %s is not supported", col.Type)
+		}
+		if t, ok := sizedUnsignedType(intTp); ok && opt.UnsignedTypes && nullStyle != NullInSql {
+			goType = t
+			if nullStyle == NullInPointer {
+				goType = "*" + goType
+			}
+		}
+		if isTinyIntBool(col.tp, opt) {
+			goType, pkg = nullGoType("bool", "", nullStyle)
+		}
+		if opt.TimeAsDuration && col.tp.Tp == mysql.TypeDuration {
+			goType, pkg = nullGoType("time.Duration", "time", nullStyle)
+		}
+		pqArray, isPqArray := pqArrayType(hint, col.tp)
+		isPqArray = isPqArray && opt.PqArrays
+		if isPqArray {
+			// the array types are nullable
+			goType, pkg = pqArray, "github.com/lib/pq"
+		} else if opt.SpatialType != "" && isSpatialColumn(col) {
+			goType, pkg = splitGoType(opt.SpatialType)
+			goType, pkg = nullGoType(goType, pkg, nullStyle)
+		} else if hint.GoType != "" && hint.ArrayDims == 0 {
+			goType, pkg = splitGoType(hint.GoType)
+			goType, pkg = nullGoType(goType, pkg, nullStyle)
+		} else if hint.Unknown && opt.UnknownTypeFallback != "" {
+			goType, pkg = splitGoType(opt.UnknownTypeFallback)
+			if hint.ArrayDims > 0 {
+				goType = strings.Repeat("[]", hint.ArrayDims) + goType
+			} else if goType != "interface{}" && goType != "any" {
+				goType, pkg = nullGoType(goType, pkg, nullStyle)
+			}
+		} else if hint.ArrayDims > 0 {
+			// elements of an array are not null
+			goType, pkg = mysqlToGoType(col.tp, NullDisable)
+			if isBoolArray(hint) {
+				goType = "bool"
+			}
+			goType = strings.Repeat("[]", hint.ArrayDims) + goType
+		}
+		// the Go type is set by type mapping or annotation
+		userType := false
+		if t, p, ok := mappedGoType(meta.Type, col.tp, opt); ok && !isPqArray {
+			goType, pkg = t, p
+			userType = true
+			if hint.ArrayDims > 0 {
+				goType = strings.Repeat("[]", hint.ArrayDims) + goType
+			} else if nullStyle == NullInPointer {
+				goType = "*" + goType
+			}
+		} else if opt.JSONDatatype && col.tp.Tp == mysql.TypeJSON && hint.ArrayDims == 0 {
+			// it's nullable
+			goType, pkg = "datatypes.JSON", "gorm.io/datatypes"
+		} else if t, p, ok := uuidGoType(col, opt); ok && !isPqArray {
+			goType, pkg = t, p
+			if hint.ArrayDims > 0 {
+				goType = strings.Repeat("[]", hint.ArrayDims) + goType
+			} else if nullStyle == NullInPointer {
+				goType = "*" + goType
+			}
+		} else if opt.EnumConstants && col.tp.Tp == mysql.TypeEnum && nullStyle != NullInSql {
+			enum, isNew := makeEnum(data.TableName+exportedName, col.Elems, ctx)
+			if isNew {
+				data.Enums = append(data.Enums, enum)
+			}
+			goType, pkg = enum.Name, ""
+			if nullStyle == NullInPointer {
+				goType = "*" + goType
+			}
+		}
+		if meta.SoftDelete {
+			switch opt.ORM {
+			case ORMXorm:
+				goType, pkg = "time.Time", "time"
+			default:
+				goType, pkg = "gorm.DeletedAt", "gorm.io/gorm"
+			}
+		}
+		if opt.PrimaryKeyType != "" && col.PrimaryKey && col.AutoIncrement {
+			goType, pkg = splitGoType(opt.PrimaryKeyType)
+		} else if opt.PrimaryKeyType != "" && col.refKey != nil && nullStyle != NullInSql {
+			// the same type as the key it references
+			goType, pkg = splitGoType(opt.PrimaryKeyType)
+			if nullStyle == NullInPointer {
+				goType, pkg = nullGoType(goType, pkg, nullStyle)
+			}
+		}
+		if col.ann.GoType != "" {
+			goType, pkg = splitGoType(col.ann.GoType)
+			userType = true
+			if nullStyle == NullInPointer && !strings.HasPrefix(goType, "*") {
+				goType = "*" + goType
+			}
+		}
+		if col.tp.Tp == mysql.TypeSet && goType == "[]string" {
+			goType = stringSetType
+			if !ctx.setType {
+				ctx.setType, declareSet = true, true
+			}
+		}
+		if opt.JSONScanner && col.tp.Tp == mysql.TypeJSON {
+			if name, ok := jsonScannerType(goType, pkg); ok {
+				if _, declared := ctx.scanners[name]; !declared {
+					ctx.scanners[name] = struct{}{}
+					scanners = append(scanners, name)
+				}
+			} else if userType && pkg != "" {
+				ctx.warn(table.Name, colName, "Scan and Value of %s can't be declared in this package", goType)
+			}
 		}
-		goType, pkg := mysqlToGoType(col.Tp, nullStyle)
 		if pkg != "" {
 			importPath = append(importPath, pkg)
 		}
 		field.GoType = goType
+		if col.PrimaryKey {
+			keys = append(keys, newRepositoryKey(colName, field.Name, goType))
+		}
+		// gorm fills auto time and auto increment fields
+		if opt.Constructor && group == nil && !col.AutoIncrement && meta.AutoTime == "" && !meta.SoftDelete {
+			if v, ok := defaultLiteral(col, goType); ok {
+				defaults = append(defaults, tmplDefaultField{Name: field.Name, Value: v})
+			}
+		}
+		if opt.StringMethod {
+			name := field.Name
+			if group != nil {
+				name = data.Fields[group.Index].Name + "." + name
+			}
+			stringFields = append(stringFields, newStringField(name, goType, redacted))
+		}
 
+		if opt.ValidateTag {
+			if v := makeValidateTag(col, meta, goType, opt); v != "" {
+				tags = append(tags, "validate", v)
+			}
+		}
+		field.Tag = makeTagStr(tags)
+
+		if group != nil {
+			group.Fields = append(group.Fields, field)
+			continue
+		}
 		data.Fields = append(data.Fields, field)
 	}
+	for _, g := range groupList {
+		f := &data.Fields[g.Index]
+		embedded, isNew := makeEmbedded(f.GoType, g.Fields, ctx)
+		if isNew {
+			data.Embedded = append(data.Embedded, embedded)
+		}
+		f.GoType = embedded.Name
+	}
+	if opt.NullAccessors {
+		data.Accessors = makeAccessors(data.Fields)
+		for _, a := range data.Accessors {
+			if a.Type == "time.Time" {
+				// value of sql.NullTime
+				importPath = append(importPath, "time")
+			}
+		}
+	}
+	if opt.Associations && opt.ORM == ORMGorm {
+		fields, pkg := makeAssociations(table, fieldNames, ctx, opt)
+		data.Fields = append(data.Fields, fields...)
+		importPath = append(importPath, pkg...)
+	}
 
+	tmpl := structTmpl
+	if opt.structTmpl != nil {
+		tmpl = opt.structTmpl
+	}
 	builder := strings.Builder{}
-	err := structTmpl.Execute(&builder, data)
+	err := tmpl.Execute(&builder, data)
 	if err != nil {
 		return "", nil, err
 	}
+	if opt.Constructor {
+		c := tmplConstructor{Model: data.TableName, Fields: defaults}
+		if err := constructorTmpl.Execute(&builder, c); err != nil {
+			return "", nil, err
+		}
+	}
+	if opt.Repository && opt.ORM == ORMGorm {
+		if repo := makeRepository(data.TableName, keys); repo != nil {
+			if err := repositoryTmpl.Execute(&builder, repo); err != nil {
+				return "", nil, err
+			}
+			importPath = append(importPath, "context", "gorm.io/gorm")
+		} else {
+			ctx.warn(table.Name, "", "repository is skipped without primary key")
+		}
+	}
+	if opt.StringMethod {
+		if str, pkg := makeStringer(data.TableName, stringFields); str != nil {
+			if err := stringerTmpl.Execute(&builder, str); err != nil {
+				return "", nil, err
+			}
+			importPath = append(importPath, pkg...)
+		} else {
+			ctx.warn(table.Name, "", "String method is skipped for field String")
+		}
+	}
+	for _, name := range scanners {
+		if err := jsonScannerTmpl.Execute(&builder, name); err != nil {
+			return "", nil, err
+		}
+		importPath = append(importPath, "database/sql/driver", "encoding/json", "fmt")
+	}
+	if declareSet {
+		if err := stringSetTmpl.Execute(&builder, stringSetType); err != nil {
+			return "", nil, err
+		}
+		importPath = append(importPath, "database/sql/driver", "fmt", "strings")
+	}
+	if opt.ColumnsHelper {
+		if cols := makeColumns(data.TableName, data.Fields, columns); cols != nil {
+			if err := columnsTmpl.Execute(&builder, cols); err != nil {
+				return "", nil, err
+			}
+		} else {
+			ctx.warn(table.Name, "", "Columns helper is skipped for field Columns")
+		}
+	}
 	code, err := format.Source([]byte(builder.String()))
 	if err != nil {
 		return string(code), importPath, errors.WithMessage(err, "format golang code error")
@@ -231,6 +810,26 @@ func makeCode(stmt *ast.CreateTableStmt, opt options) (string, []string, error)
 }
 
 func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path string) {
+	if colTp.Charset == "binary" && (isCharType(colTp.Tp) || isTextType(colTp.Tp)) {
+		// blob, binary and varbinary, slices are nullable
+		return "[]byte", ""
+	}
+	switch colTp.Tp {
+	case mysql.TypeSet:
+		// MySQL joins the values by comma, it's StringSet of models
+		return "[]string", ""
+	case mysql.TypeBit:
+		if colTp.Flen <= 1 {
+			return nullGoType("bool", "", style)
+		}
+		// the driver returns big-endian bytes, which can't be scanned to uint64
+		return "[]byte", ""
+	case mysql.TypeYear:
+		return nullGoType("int16", "", style)
+	case mysql.TypeDuration:
+		// time of day or elapsed time like 838:59:59
+		return nullGoType("string", "", style)
+	}
 	if style == NullInSql {
 		path = "database/sql"
 		switch colTp.Tp {
@@ -247,7 +846,7 @@ func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path s
 			name = "sql.NullTime"
 		case mysql.TypeDecimal, mysql.TypeNewDecimal:
 			name = "sql.NullString"
-		case mysql.TypeJSON:
+		case mysql.TypeJSON, mysql.TypeEnum:
 			name = "sql.NullString"
 		default:
 			return "UnSupport", ""
@@ -276,7 +875,7 @@ func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path s
 			name = "time.Time"
 		case mysql.TypeDecimal, mysql.TypeNewDecimal:
 			name = "string"
-		case mysql.TypeJSON:
+		case mysql.TypeJSON, mysql.TypeEnum:
 			name = "string"
 		default:
 			return "UnSupport", ""
@@ -288,12 +887,185 @@ func mysqlToGoType(colTp *types.FieldType, style NullStyle) (name string, path s
 	return
 }
 
+// isSoftDeleteColumn reports whether a nullable time column is used for soft
+// delete, name is the column name without prefix.
+func isSoftDeleteColumn(name string, colTp *types.FieldType, meta columnMeta, opt options) bool {
+	if meta.NotNull || meta.PrimaryKey {
+		return false
+	}
+	switch colTp.Tp {
+	case mysql.TypeTimestamp, mysql.TypeDatetime:
+	default:
+		return false
+	}
+	for _, c := range opt.SoftDelete {
+		if strings.EqualFold(name, c) {
+			return true
+		}
+	}
+	return false
+}
+
+// getAutoTime recognizes created_at, updated_at and columns of ON UPDATE
+// CURRENT_TIMESTAMP managed by gorm, unixTime is the unit of integer columns.
+func getAutoTime(name string, col ColumnInfo) (autoTime string, unixTime string) {
+	switch strings.ToLower(name) {
+	case "created_at":
+		autoTime = "create"
+	case "updated_at":
+		autoTime = "update"
+	default:
+		if col.OnUpdate == "" {
+			return "", ""
+		}
+		// MySQL allows only the current time in ON UPDATE
+		autoTime = "update"
+	}
+	switch col.tp.Tp {
+	case mysql.TypeTimestamp, mysql.TypeDatetime:
+	case mysql.TypeLong, mysql.TypeInt24:
+		unixTime = "sec"
+	case mysql.TypeLonglong:
+		// seconds fit in int, bigint is likely milliseconds
+		unixTime = "milli"
+	default:
+		return "", ""
+	}
+	return
+}
+
+// sortColumns returns columns in the order of fields
+func sortColumns(cols []ColumnInfo, opt options) []ColumnInfo {
+	if opt.FieldOrder == OrderDeclaration {
+		return cols
+	}
+	sorted := make([]ColumnInfo, len(cols))
+	copy(sorted, cols)
+	switch opt.FieldOrder {
+	case OrderAlphabetical:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(trimColumnPrefix(sorted[i].Name, opt)) < strings.ToLower(trimColumnPrefix(sorted[j].Name, opt))
+		})
+	case OrderPrimaryKeyFirst:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].PrimaryKey && !sorted[j].PrimaryKey
+		})
+	}
+	return sorted
+}
+
+// jsonName returns the name of column in json tag
+func jsonName(column string, opt options) string {
+	switch opt.JsonTagStyle {
+	case JsonCamelCase:
+		return lowerCamel(column)
+	case JsonSnakeCase:
+		return toSnake(toCamel(column))
+	}
+	return column
+}
+
+// unexportedName lowers the leading upper case letters of an exported name,
+// e.g. UserID to userID, HTTPCode to httpCode. Keywords get suffix "_".
+func unexportedName(s string) string {
+	n := 0
+	for n < len(s) && s[n] >= 'A' && s[n] <= 'Z' {
+		n++
+	}
+	if n > 1 && n < len(s) && s[n] >= 'a' && s[n] <= 'z' {
+		// the last upper case letter begins the next word
+		n--
+	}
+	s = strings.ToLower(s[:n]) + s[n:]
+	if gotoken.IsKeyword(s) {
+		s += "_"
+	}
+	return s
+}
+
+// lowerCamel converts a column name to lower camel case without initialisms,
+// e.g. user_id to userId
+func lowerCamel(s string) string {
+	if strings.ToUpper(s) == s {
+		// ID, USER_ID
+		s = strings.ToLower(s)
+	}
+	b := strings.Builder{}
+	b.Grow(len(s))
+	upper := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' || c == ' ' || c == '-' || c == '.':
+			upper = b.Len() > 0
+			continue
+		case b.Len() == 0 && c >= 'A' && c <= 'Z':
+			c += 'a' - 'A'
+		case upper && c >= 'a' && c <= 'z':
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// structName returns the struct name of table
+func structName(table string, opt options) string {
+	if name, ok := opt.StructNames[strings.ToLower(table)]; ok {
+		return name
+	}
+	name := trimTableName(table, opt)
+	if opt.SingularStruct {
+		name = inflection.Singular(name)
+	}
+	name = opt.StructNamePrefix + toCamel(name)
+	if name == "" || isDigit(name[0]) {
+		name = "Table" + name
+	}
+	return name
+}
+
+// trimTableName removes the prefix and suffix of table name
+func trimTableName(table string, opt options) string {
+	if opt.TablePrefix != "" && strings.HasPrefix(table, opt.TablePrefix) {
+		table = table[len(opt.TablePrefix):]
+	}
+	if opt.TableSuffix != "" && len(table) > len(opt.TableSuffix) && strings.HasSuffix(table, opt.TableSuffix) {
+		table = table[:len(table)-len(opt.TableSuffix)]
+	}
+	return table
+}
+
+func trimColumnPrefix(col string, opt options) string {
+	if opt.ColumnPrefix != "" && strings.HasPrefix(col, opt.ColumnPrefix) {
+		return col[len(opt.ColumnPrefix):]
+	}
+	return col
+}
+
+// matchColumn reports whether a column name matches one of the glob patterns,
+// case insensitive
+func matchColumn(col string, patterns []string) bool {
+	col = strings.ToLower(col)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), col); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// tagValueReplacer escapes a value in struct tag, which is a quoted string in
+// a raw string literal, backquote can't be escaped and is replaced.
+var tagValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "`", "'")
+
 func makeTagStr(tags []string) string {
 	builder := strings.Builder{}
 	for i := 0; i < len(tags)/2; i++ {
 		builder.WriteString(tags[i*2])
 		builder.WriteString(`:"`)
-		builder.WriteString(tags[i*2+1])
+		builder.WriteString(tagValueReplacer.Replace(tags[i*2+1]))
 		builder.WriteString(`" `)
 	}
 	if builder.Len() > 0 {
@@ -302,7 +1074,27 @@ func makeTagStr(tags []string) string {
 	return builder.String()
 }
 
+// commentLines splits a comment into lines for line comments
+func commentLines(s string) []string {
+	s = strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	if s == "" {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(strings.ReplaceAll(l, "\r", " "))
+	}
+	return lines
+}
+
 func getDefaultValue(expr ast.ExprNode) (value string) {
+	if u, ok := expr.(*ast.UnaryOperationExpr); ok && u.Op == opcode.Minus {
+		// DEFAULT -1
+		if v := getDefaultValue(u.V); v != "" {
+			return "-" + v
+		}
+		return ""
+	}
 	if expr.GetDatum().Kind() != types.KindNull {
 		value = fmt.Sprintf("%v", expr.GetDatum().GetValue())
 	} else if expr.GetFlag() != ast.FlagConstant {
@@ -358,6 +1150,36 @@ func toCamel(s string) string {
 	return n.String()
 }
 
+// toSnake converts a name like UserProfile to user_profile
+func toSnake(s string) string {
+	b := strings.Builder{}
+	b.Grow(len(s) + 4)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			// the start of a word, e.g. "P" of "UserProfile" and "ID" of "UserID"
+			if i > 0 && isLowerOrDigit(s[i-1]) || i > 0 && i+1 < len(s) && s[i-1] >= 'A' && s[i-1] <= 'Z' && s[i+1] >= 'a' && s[i+1] <= 'z' {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		} else if c == ' ' || c == '-' || c == '.' {
+			c = '_'
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func isLowerOrDigit(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= '0' && c <= '9'
+}
+
+// DefaultTemplate returns the template of struct, it's a start of WithTemplate
+func DefaultTemplate() string {
+	initTemplate()
+	return structTmplRaw
+}
+
 func initTemplate() {
 	tmplParseOnce.Do(
 		func() {
@@ -376,20 +1198,73 @@ func initTemplate() {
 
 func init() {
 	structTmplRaw = `
-{{- if .Comment -}}
-// {{.Comment}}
+{{- range .Enums -}}
+type {{.Name}} string
+
+const (
+{{- $type := .Name}}
+{{- range .Values}}
+	{{.Name}} {{$type}} = {{printf "%q" .Value}}
+{{- end}}
+)
+
+{{end -}}
+{{- range .Embedded -}}
+type {{.Name}} struct {
+{{- range .Fields}}
+{{- range .Doc}}
+	// {{.}}
+{{- end}}
+	{{.Name}}{{if .GoType}} {{.GoType}}{{end}}{{if .Tag}} ` + "`{{.Tag}}`" + `{{end}}{{if .Comment}} // {{.Comment}}{{end}}
+{{- end}}
+}
+
+{{end -}}
+{{- if .NameConst -}}
+// {{.TableName}}TableName is the name of table of {{.TableName}}
+const {{.TableName}}TableName = {{printf "%q" .RawTableName}}
+
+{{end -}}
+{{- range .Comment -}}
+// {{.}}
 {{end -}}
 type {{.TableName}} struct {
 {{- range .Fields}}
-	{{.Name}} {{.GoType}} {{if .Tag}}` + "`{{.Tag}}`" + `{{end}}{{if .Comment}} // {{.Comment}}{{end}}
+{{- range .Doc}}
+	// {{.}}
+{{- end}}
+	{{.Name}}{{if .GoType}} {{.GoType}}{{end}}{{if .Tag}} ` + "`{{.Tag}}`" + `{{end}}{{if .Comment}} // {{.Comment}}{{end}}
 {{- end}}
 }
 {{if .NameFunc}}
 func (m *{{.TableName}}) TableName() string {
-	return "{{.RawTableName}}"
+	return {{if .NameConst}}{{.TableName}}TableName{{else}}"{{.RawTableName}}"{{end}}
+}
+{{end}}
+{{- range .Accessors}}
+// {{.Getter}} returns {{.Field}} and false if it's NULL
+func (m *{{$.TableName}}) {{.Getter}}() ({{.Type}}, bool) {
+{{- if .Value}}
+	return m.{{.Field}}.{{.Value}}, m.{{.Field}}.Valid
+{{- else}}
+	if m.{{.Field}} == nil {
+		var v {{.Type}}
+		return v, false
+	}
+	return *m.{{.Field}}, true
+{{- end}}
+}
+
+// {{.Setter}} sets {{.Field}} to v which is not NULL
+func (m *{{$.TableName}}) {{.Setter}}(v {{.Type}}) {
+{{- if .Value}}
+	m.{{.Field}} = {{.Null}}{ {{- .Value}}: v, Valid: true}
+{{- else}}
+	m.{{.Field}} = &v
+{{- end}}
 }
 {{end}}`
-	fileTmplRaw = `// Code generated by github.com/cascax/sql2gorm
+	fileTmplRaw = fileHeaderTmpl + `
 package {{.Package}}
 {{if .ImportPath}}
 import (
@@ -403,3 +1278,19 @@ import (
 {{end}}
 `
 }
+
+// columnCharset gets character set and collation of a string column, they are
+// declared by the column, or the default of table, or WithCharset and
+// WithCollation if the SQL omits them.
+func columnCharset(table TableInfo, col ColumnInfo, opt options) (string, string) {
+	if col.tp.EvalType() != types.ETString || col.tp.Charset == "binary" {
+		return "", ""
+	}
+	if col.Charset != "" || col.Collation != "" {
+		return col.Charset, col.Collation
+	}
+	if table.Charset != "" || table.Collation != "" {
+		return table.Charset, table.Collation
+	}
+	return opt.Charset, opt.Collation
+}
@@ -1,12 +1,18 @@
 package parser
 
 import (
+	"bytes"
+	"flag"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"path/filepath"
 	"strings"
 	"testing"
 )
 
+var update = flag.Bool("update", false, "update golden files in testdata")
+
 var testData = [][]string{
 	{
 		"CREATE TABLE information (age INT(11) NULL);",
@@ -18,7 +24,7 @@ var testData = [][]string{
 	},
 	{
 		"CREATE TABLE information (id BIGINT(11) PRIMARY KEY AUTO_INCREMENT);",
-		"ID int64 `gorm:\"column:id;primary_key;AUTO_INCREMENT\"`", "",
+		"ID uint `gorm:\"column:id;primaryKey;autoIncrement\"`", "",
 	},
 	{
 		"CREATE TABLE information (user_ip varchar(20));",
@@ -107,3 +113,2245 @@ func TestParseSql1(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSqlNullStyleImports(t *testing.T) {
+	tests := []struct {
+		column  string
+		options []Option
+		sql     []string // imports of NullInSql
+		ptr     []string // imports of NullInPointer
+		none    []string // imports of NullDisable
+	}{
+		{"x int NULL", nil, []string{"database/sql"}, nil, nil},
+		{"x bigint unsigned NULL", nil, []string{"database/sql"}, nil, nil},
+		{"x int unsigned NULL", []Option{WithUnsignedTypes()}, []string{"database/sql"}, nil, nil},
+		{"x varchar(8) NULL", nil, []string{"database/sql"}, nil, nil},
+		{"x varchar(8) NOT NULL", nil, nil, nil, nil},
+		{"x decimal(10,2) NULL", nil, []string{"database/sql"}, nil, nil},
+		{"x tinyint(1) NULL", []Option{WithTinyIntBool()}, []string{"database/sql"}, nil, nil},
+		{"x bit(1) NULL", nil, []string{"database/sql"}, nil, nil},
+		{"x bit(8) NULL", nil, nil, nil, nil},
+		{"x datetime NULL", nil, []string{"database/sql"}, []string{"time"}, []string{"time"}},
+		{"x datetime NULL", []Option{WithNullAccessors()}, []string{"database/sql", "time"}, []string{"time"}, []string{"time"}},
+		{"x date NOT NULL", nil, []string{"time"}, []string{"time"}, []string{"time"}},
+		{"x time NULL", nil, []string{"database/sql"}, nil, nil},
+		{"x time NULL", []Option{WithTimeAsDuration()}, []string{"time"}, []string{"time"}, []string{"time"}},
+		{"x year NULL", nil, nil, nil, nil},
+		{"x set('a','b') NULL", nil, []string{"database/sql/driver", "fmt", "strings"},
+			[]string{"database/sql/driver", "fmt", "strings"}, []string{"database/sql/driver", "fmt", "strings"}},
+		{"x enum('a','b') NULL", []Option{WithEnumConstants()}, []string{"database/sql"}, nil, nil},
+		{"deleted_at datetime NULL", []Option{WithSoftDelete()}, []string{"gorm.io/gorm"}, []string{"gorm.io/gorm"}, []string{"gorm.io/gorm"}},
+		{"x json NULL", []Option{WithJSONDatatype()}, []string{"gorm.io/datatypes"}, []string{"gorm.io/datatypes"}, []string{"gorm.io/datatypes"}},
+		{"x char(36) NULL", []Option{WithUUIDType("github.com/google/uuid.UUID")},
+			[]string{"github.com/google/uuid"}, []string{"github.com/google/uuid"}, []string{"github.com/google/uuid"}},
+	}
+	for _, test := range tests {
+		sql := "CREATE TABLE t (id int PRIMARY KEY, " + test.column + ");"
+		for _, style := range []struct {
+			style   NullStyle
+			imports []string
+		}{{NullInSql, test.sql}, {NullInPointer, test.ptr}, {NullDisable, test.none}} {
+			msg := fmt.Sprintf("%s of null style %d", test.column, style.style)
+			data, err := ParseSql(sql, append([]Option{WithNullStyle(style.style)}, test.options...)...)
+			if !assert.NoError(t, err, msg) {
+				continue
+			}
+			if style.imports == nil {
+				style.imports = []string{}
+			}
+			assert.Equal(t, style.imports, data.ImportPath, msg)
+		}
+	}
+}
+
+func TestParseSqlAssociations(t *testing.T) {
+	sql := `CREATE TABLE users (id BIGINT PRIMARY KEY);
+CREATE TABLE t_categories (
+  id BIGINT PRIMARY KEY,
+  parent_id BIGINT REFERENCES t_categories(id)
+);
+CREATE TABLE orders (
+  id BIGINT PRIMARY KEY,
+  user_id BIGINT NOT NULL,
+  shop_id BIGINT,
+  region VARCHAR(10),
+  code VARCHAR(10),
+  FOREIGN KEY (user_id) REFERENCES users(id),
+  FOREIGN KEY (shop_id) REFERENCES shops(id),
+  FOREIGN KEY (region, code) REFERENCES t_categories(region, code)
+);`
+	data, err := ParseSql(sql, WithAssociations(), WithTablePrefix("t_"), WithJsonTag(), WithNoNullType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 3, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[1], "Parent   *Categories `gorm:\"foreignKey:ParentID;references:ID\" json:\"parent\"`")
+	assert.Contains(t, data.StructCode[2], "User       Users      `gorm:\"foreignKey:UserID;references:ID\" json:\"user\"`")
+	assert.Contains(t, data.StructCode[2], "Shop       Shops      `gorm:\"foreignKey:ShopID;references:ID\" json:\"shop\"`")
+	assert.Contains(t, data.StructCode[2], "Categories Categories `gorm:\"foreignKey:Region,Code;references:Region,Code\" json:\"categories\"`")
+}
+
+func TestParseSqlAssociationJsonStyle(t *testing.T) {
+	sql := `CREATE TABLE users (id BIGINT PRIMARY KEY);
+CREATE TABLE posts (
+  id BIGINT PRIMARY KEY,
+  AuthorID BIGINT,
+  buyer_user_id BIGINT,
+  FOREIGN KEY (AuthorID) REFERENCES users(id),
+  FOREIGN KEY (buyer_user_id) REFERENCES users(id)
+);`
+	for style, keys := range map[JsonTagStyle][]string{
+		JsonKeepColumn: {"author", "buyer_user"},
+		JsonCamelCase:  {"author", "buyerUser"},
+		JsonSnakeCase:  {"author", "buyer_user"},
+	} {
+		data, err := ParseSql(sql, WithAssociations(), WithJsonTag(), WithJsonTagStyle(style))
+		if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+			continue
+		}
+		assert.Contains(t, data.StructCode[1], "json:\""+keys[0]+"\"`", style)
+		assert.Contains(t, data.StructCode[1], "json:\""+keys[1]+"\"`", style)
+	}
+}
+
+func TestParseSqlAssociationPointers(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY, manager_id int NULL REFERENCES users(id));
+CREATE TABLE orders (id int PRIMARY KEY, user_id int NOT NULL REFERENCES users(id));`
+	data, err := ParseSql(sql, WithAssociations(), WithAssociationPointers())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "Manager   *Users")
+	assert.Contains(t, data.StructCode[1], "UserID int    `gorm:\"column:user_id;NOT NULL\"`")
+	assert.Contains(t, data.StructCode[1], "User   *Users `gorm:\"foreignKey:UserID;references:ID\"`")
+}
+
+func TestParseSqlExternalPackage(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE items (
+  id int PRIMARY KEY,
+  order_id int REFERENCES orders(id),
+  user_id int REFERENCES users(id),
+  shop_id int REFERENCES shops(id)
+);`
+	data, err := ParseSql(sql, WithAssociations(),
+		WithExternalPackage("example.com/shop/order"),
+		WithTablePackageMap(map[string]string{"Users": "example.com/account", "shops": "example.com/shop/v2"}))
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[1]
+	assert.Contains(t, code, "Order   order.Orders  `gorm:\"foreignKey:OrderID;references:ID\"`")
+	assert.Contains(t, code, "User    account.Users `gorm:\"foreignKey:UserID;references:ID\"`")
+	assert.Contains(t, code, "Shop    shop.Shops    `gorm:\"foreignKey:ShopID;references:ID\"`")
+	assert.Equal(t, []string{"example.com/account", "example.com/shop/order", "example.com/shop/v2"}, data.ImportPath)
+}
+
+func TestParseSqlViews(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY, name varchar(20) NOT NULL, age int);
+CREATE TABLE orders (id bigint NOT NULL AUTO_INCREMENT PRIMARY KEY, uid int NOT NULL, total decimal(10,2) NOT NULL DEFAULT 0);
+CREATE OR REPLACE ALGORITHM=MERGE DEFINER=` + "`root`@`localhost`" + ` VIEW v_adults (uid, uname) AS
+  SELECT id, name FROM users WHERE age >= 18 WITH CHECK OPTION;
+CREATE VIEW v_order_users AS
+  SELECT o.*, u.name AS user_name, count(*) AS n, u.age + 1 AS next_age FROM orders o LEFT JOIN users u ON u.id = o.uid;`
+
+	data, err := ParseSql(sql)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, len(data.StructCode))
+	}
+
+	tables, err := ParseTables(sql, WithIncludeViews())
+	if assert.NoError(t, err) && assert.Equal(t, 4, len(tables)) {
+		assert.False(t, tables[1].View)
+		assert.True(t, tables[2].View)
+		assert.Equal(t, "v_adults", tables[2].Name)
+	}
+
+	data, err = ParseSql(sql, WithIncludeViews())
+	if !assert.NoError(t, err) || !assert.Equal(t, 4, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[2], "Uid   int    `gorm:\"column:uid;->;NOT NULL\"`")
+	assert.Contains(t, data.StructCode[2], "Uname string `gorm:\"column:uname;->;NOT NULL\"`")
+	code := data.StructCode[3]
+	assert.Contains(t, code, "ID       int64          `gorm:\"column:id;->;NOT NULL\"`")
+	assert.Contains(t, code, "Total    string         `gorm:\"column:total;->;NOT NULL\"`")
+	// users is on the right of LEFT JOIN
+	assert.Contains(t, code, "UserName sql.NullString `gorm:\"column:user_name;->\"`")
+	assert.Contains(t, code, "N        int64          `gorm:\"column:n;->;NOT NULL\"`")
+	assert.NotContains(t, code, "NextAge")
+	assert.NotContains(t, code, "primaryKey")
+}
+
+func TestParseSqlWarnings(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY, team_id int REFERENCES teams (id));
+ALTER TABLE orders ADD COLUMN note text;
+CREATE VIEW v_users AS SELECT id FROM users;`
+	data, err := ParseSql(sql, WithAssociations())
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Warning{
+			{Table: "orders", Message: "ALTER TABLE is skipped, the table is not created before"},
+			{Message: "1 view is skipped"},
+			{Table: "users", Message: "it references table teams which is not in the input"},
+		}, data.Warnings)
+	}
+
+	data, err = ParseSql(sql + "\nCREATE VIEW v_teams AS SELECT team_id FROM users;")
+	if assert.NoError(t, err) {
+		assert.Contains(t, data.Warnings, Warning{Message: "2 views are skipped"})
+	}
+
+	sql = `CREATE TABLE t (id int PRIMARY KEY, m mood, r int, EXCLUDE USING gist (r WITH =));`
+	data, err = ParseSql(sql, WithDialect(DialectPostgres))
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.Warnings)) {
+		assert.Equal(t, "t.m: unknown type mood is mapped to text", data.Warnings[0].String())
+		assert.Equal(t, "t: EXCLUDE constraint is dropped", data.Warnings[1].String())
+	}
+
+	data, err = ParseSql("CREATE TABLE t (id int PRIMARY KEY);")
+	if assert.NoError(t, err) {
+		assert.Empty(t, data.Warnings)
+	}
+}
+
+func TestParseSqlAlterTable(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY, legacy varchar(8) NULL);
+ALTER TABLE users ADD COLUMN phone varchar(20) NOT NULL COMMENT '@json:mobile', DROP COLUMN legacy;`
+	data, err := ParseSql(sql, WithAnnotations(), WithJsonTag())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Phone string `gorm:\"column:phone;NOT NULL\" json:\"mobile\"`")
+		assert.NotContains(t, data.StructCode[0], "Legacy")
+	}
+}
+
+func TestParseSqlIndexTags(t *testing.T) {
+	sql := `CREATE TABLE users (
+  user_id INT NOT NULL,
+  tenant_id INT NOT NULL,
+  email VARCHAR(64),
+  bio TEXT,
+  KEY idx_email (email),
+  UNIQUE KEY uk_user (user_id, tenant_id),
+  INDEX (tenant_id),
+  FULLTEXT KEY ft_bio (bio)
+);`
+	data, err := ParseSql(sql, WithIndexTags(), WithNoNullType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "`gorm:\"column:user_id;uniqueIndex:uk_user,priority:1;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:tenant_id;uniqueIndex:uk_user,priority:2;index:tenant_id;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:email;index:idx_email\"`")
+	assert.Contains(t, code, "`gorm:\"column:bio;index:ft_bio,class:FULLTEXT\"`")
+
+	data, err = ParseSql(sql, WithNoNullType())
+	if assert.NoError(t, err) {
+		assert.NotContains(t, data.StructCode[0], "index")
+	}
+}
+
+func TestParseSqlUnnamedIndexTags(t *testing.T) {
+	sql := `CREATE TABLE orders (
+  user_id INT NOT NULL,
+  status INT NOT NULL,
+  a INT NOT NULL,
+  b INT NOT NULL,
+  note TEXT NOT NULL,
+  KEY (user_id, status),
+  UNIQUE KEY (b, a),
+  KEY (b),
+  FULLTEXT KEY (note)
+);`
+	data, err := ParseSql(sql, WithIndexTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	// named by the first column like MySQL, gorm splits an index without name
+	code := data.StructCode[0]
+	assert.Contains(t, code, "`gorm:\"column:user_id;index:user_id,priority:1;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:status;index:user_id,priority:2;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:a;uniqueIndex:b,priority:2;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:b;uniqueIndex:b,priority:1;index:b_2;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:note;index:note,class:FULLTEXT;NOT NULL\"`")
+
+	sql = `CREATE TABLE t (uid int NOT NULL, m int NOT NULL, UNIQUE (uid, m));
+CREATE INDEX ON t (m);`
+	data, err = ParseSql(sql, WithIndexTags(), WithDialect(DialectPostgres))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:uid;uniqueIndex:t_uid_m_key,priority:1;NOT NULL\"`")
+		assert.Contains(t, data.StructCode[0], "uniqueIndex:t_uid_m_key,priority:2;")
+	}
+}
+
+func TestParseSqlComments(t *testing.T) {
+	sql := "CREATE TABLE users (email VARCHAR(64) COMMENT 'the \"email\";\\nused to `login`') COMMENT 'user\\naccounts';"
+	data, err := ParseSql(sql, WithComments(), WithCommentTags(), WithNoNullType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	lines := strings.Split(strings.TrimSpace(data.StructCode[0]), "\n")
+	assert.Equal(t, []string{
+		"// user",
+		"// accounts",
+		"type Users struct {",
+		"\t// the \"email\";",
+		"\t// used to `login`",
+		"\tEmail string `gorm:\"column:email;comment:the \\\"email\\\"\\\\; used to 'login'\"`",
+		"}",
+	}, lines)
+
+	data, err = ParseSql(sql, WithNoNullType())
+	if assert.NoError(t, err) {
+		assert.Contains(t, data.StructCode[0], "Email string `gorm:\"column:email\"` // the \"email\"; used to `login`")
+	}
+}
+
+func TestParseSqlXorm(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id BIGINT PRIMARY KEY AUTO_INCREMENT,
+  email VARCHAR(255) NOT NULL DEFAULT 'it''s' COMMENT 'mail',
+  age INT NULL DEFAULT 18,
+  UNIQUE KEY uk_email (email)
+);`
+	data, err := ParseSql(sql, WithORM(ORMXorm), WithGormType(), WithIndexTags(), WithNoNullType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "`xorm:\"bigint(20) pk autoincr 'id'\"`")
+	assert.Contains(t, code, "`xorm:\"varchar(255) not null default 'it''s' unique(uk_email) 'email'\"`")
+	assert.Contains(t, code, "`xorm:\"int(11) null default 18 'age'\"`")
+	assert.NotContains(t, code, "gorm")
+}
+
+func TestParseSqlSoftDelete(t *testing.T) {
+	sql := `CREATE TABLE users (
+  f_deleted_at DATETIME NULL,
+  undeleted_at_time DATETIME NULL,
+  removed TIMESTAMP NULL
+);`
+	data, err := ParseSql(sql, WithSoftDelete(), WithColumnPrefix("f_"))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "DeletedAt       gorm.DeletedAt `gorm:\"column:f_deleted_at;index\"`")
+	assert.Contains(t, data.StructCode[0], "UndeletedAtTime sql.NullTime")
+	assert.Equal(t, []string{"database/sql", "gorm.io/gorm"}, data.ImportPath)
+
+	data, err = ParseSql(sql, WithSoftDelete("removed"))
+	if assert.NoError(t, err) {
+		assert.Contains(t, data.StructCode[0], "Removed         gorm.DeletedAt")
+		assert.Contains(t, data.StructCode[0], "FDeletedAt      sql.NullTime")
+	}
+}
+
+func TestParseSqlGormTimestamps(t *testing.T) {
+	sql := `CREATE TABLE users (
+  created_at DATETIME NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at INT NULL
+);
+CREATE TABLE orders (
+  created_at BIGINT NOT NULL,
+  updated_at BIGINT NOT NULL
+);`
+	data, err := ParseSql(sql, WithGormTimestamps())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "CreatedAt time.Time `gorm:\"column:created_at\"`")
+	assert.Contains(t, data.StructCode[0], "UpdatedAt int       `gorm:\"column:updated_at;autoUpdateTime\"`")
+	assert.Contains(t, data.StructCode[1], "CreatedAt int64 `gorm:\"column:created_at;autoCreateTime:milli;NOT NULL\"`")
+	assert.Contains(t, data.StructCode[1], "UpdatedAt int64 `gorm:\"column:updated_at;autoUpdateTime:milli;NOT NULL\"`")
+	assert.Equal(t, []string{"time"}, data.ImportPath)
+}
+
+func TestParseSqlOnUpdateCurrentTimestamp(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int NOT NULL PRIMARY KEY,
+  updated_at timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  modified datetime(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  seen_at timestamp(6) NULL DEFAULT NOW(6) ON UPDATE now(6)
+);`
+	tables, err := ParseTables(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.Equal(t, "CURRENT_TIMESTAMP", tables[0].Columns[1].OnUpdate)
+		assert.Equal(t, "CURRENT_TIMESTAMP(3)", tables[0].Columns[2].Default)
+		assert.Equal(t, "CURRENT_TIMESTAMP(3)", tables[0].Columns[2].OnUpdate)
+		assert.Equal(t, "CURRENT_TIMESTAMP(6)", tables[0].Columns[3].Default)
+		assert.Equal(t, "CURRENT_TIMESTAMP(6)", tables[0].Columns[3].OnUpdate)
+	}
+
+	data, err := ParseSql(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL\"`")
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:modified;default:CURRENT_TIMESTAMP(3);NOT NULL\"`")
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:seen_at;default:CURRENT_TIMESTAMP(6)\"`")
+	}
+
+	data, err = ParseSql(sql, WithGormTimestamps())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:updated_at;NOT NULL\"`")
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:modified;autoUpdateTime;NOT NULL\"`")
+	}
+}
+
+func TestParseSqlTypeMapping(t *testing.T) {
+	sql := `CREATE TABLE orders (
+  amount DECIMAL(18,2) NOT NULL,
+  rate DECIMAL(5,4) NULL,
+  total BIGINT UNSIGNED NOT NULL,
+  extra JSON
+);`
+	data, err := ParseSql(sql, WithNullStyle(NullInPointer), WithTypeMapping(map[string]string{
+		"DECIMAL":         "github.com/shopspring/decimal.Decimal",
+		"bigint unsigned": "uint64",
+		"json":            "json.RawMessage",
+	}))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "Amount decimal.Decimal ")
+	assert.Contains(t, code, "Rate   *decimal.Decimal ")
+	assert.Contains(t, code, "Total  uint64 ")
+	assert.Contains(t, code, "Extra  json.RawMessage ")
+	assert.Equal(t, []string{"github.com/shopspring/decimal"}, data.ImportPath)
+}
+
+func TestParseSqlUnsignedTypes(t *testing.T) {
+	sql := `CREATE TABLE counters (
+  id bigint(20)   UNSIGNED NOT NULL AUTO_INCREMENT,
+  hits int(10) unsigned zerofill NOT NULL,
+  flags TINYINT UNSIGNED NULL,
+  level smallint unsigned NOT NULL DEFAULT '0',
+  size MEDIUMINT NOT NULL,
+  PRIMARY KEY (id)
+);`
+	data, err := ParseSql(sql, WithNullStyle(NullInPointer), WithUnsignedTypes(), WithPrimaryKeyType(""))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "ID    uint64 ")
+	assert.Contains(t, code, "Hits  uint32 ")
+	assert.Contains(t, code, "Flags *uint8 ")
+	assert.Contains(t, code, "Level uint16 ")
+	assert.Contains(t, code, "Size  int ")
+
+	data, err = ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "Hits  uint ")
+}
+
+func TestParseSqlDecimalType(t *testing.T) {
+	sql := `CREATE TABLE accounts (
+  balance decimal(10,2) NOT NULL,
+  rate NUMERIC(5, 4) NOT NULL,
+  points int NOT NULL
+);`
+	data, err := ParseSql(sql, WithGormType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], `gorm:"column:balance;type:decimal(10,2);NOT NULL"`)
+	assert.Contains(t, data.StructCode[0], `gorm:"column:rate;type:decimal(5,4);NOT NULL"`)
+
+	data, err = ParseSql(sql, WithDecimalType("github.com/shopspring/decimal.Decimal"),
+		WithTypeMapping(map[string]string{"decimal(5,4)": "float64"}))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "Balance decimal.Decimal ")
+	assert.Contains(t, code, "Rate    float64 ")
+	assert.Contains(t, code, "Points  int ")
+	assert.Equal(t, []string{"github.com/shopspring/decimal"}, data.ImportPath)
+}
+
+func TestParseSqlEnumConstants(t *testing.T) {
+	sql := `CREATE TABLE users (
+  status ENUM('active','inactive','banned') NOT NULL DEFAULT 'active',
+  kind enum('a b','a-b','it''s','') NULL
+);
+CREATE TABLE users (
+  status ENUM('active','inactive','banned') NOT NULL
+);`
+	data, err := ParseSql(sql, WithEnumConstants(), WithGormType(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `type UsersStatus string
+
+const (
+	UsersStatusActive   UsersStatus = "active"
+	UsersStatusInactive UsersStatus = "inactive"
+	UsersStatusBanned   UsersStatus = "banned"
+)`)
+	assert.Contains(t, code, `UsersKindAB    UsersKind = "a b"
+	UsersKindAB2   UsersKind = "a-b"
+	UsersKindIts   UsersKind = "it's"
+	UsersKindEmpty UsersKind = ""`)
+	assert.Contains(t, code, "Status UsersStatus ")
+	assert.Contains(t, code, "Kind   *UsersKind ")
+	assert.Contains(t, code, `type:enum('active','inactive','banned')`)
+	assert.NotContains(t, data.StructCode[1], "type UsersStatus string")
+	assert.Contains(t, data.StructCode[1], "Status UsersStatus ")
+}
+
+func TestParseSqlMultipleTables(t *testing.T) {
+	sql := "-- MySQL dump 10.13\n" +
+		"/*!40101 SET NAMES utf8mb4 */;\n" +
+		"SET FOREIGN_KEY_CHECKS = 0;\n" +
+		"DROP TABLE IF EXISTS `users`;\n" +
+		"CREATE TABLE `users` (\n" +
+		"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` varchar(32) NOT NULL DEFAULT '' COMMENT \"user's; name\",\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n" +
+		"LOCK TABLES `users` WRITE;\n" +
+		"INSERT INTO `users` VALUES (1,'a;b'),(2,'c\\'d');\n" +
+		"UNLOCK TABLES;\n" +
+		"CREATE TEMPORARY TABLE `posts` (`id` int(11) NOT NULL, `created_at` datetime NOT NULL);\n" +
+		"DROP VIEW IF EXISTS v;\n" +
+		"DELIMITER ;;\n" +
+		"CREATE TRIGGER t BEFORE INSERT ON posts FOR EACH ROW BEGIN SET NEW.id = 1; END ;;\n" +
+		"DELIMITER ;\n" +
+		"CREATE TABLE IF NOT EXISTS `tags` (`name` varchar(10));\n"
+	data, err := ParseSql(sql, WithComments())
+	if !assert.NoError(t, err) || !assert.Equal(t, 3, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "type Users struct")
+	assert.Contains(t, data.StructCode[0], "// user's; name")
+	assert.Contains(t, data.StructCode[1], "type Posts struct")
+	assert.Contains(t, data.StructCode[2], "type Tags struct")
+	assert.Equal(t, []string{"time"}, data.ImportPath)
+
+	_, err = ParseSql("DROP TABLE a;\nCREATE TABLE a (id int);\n\nCREATE TABLE b (id int,);")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "line 4 ")
+	}
+}
+
+func TestParseSqlToFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "model")
+	sql := `CREATE TABLE user_profile (
+  id int NOT NULL,
+  created_at datetime NOT NULL
+);
+CREATE TABLE ShopOrder (id int NOT NULL);`
+	if !assert.NoError(t, ParseSqlToFiles(sql, dir)) {
+		return
+	}
+	b, err := ioutil.ReadFile(filepath.Join(dir, "user_profile.go"))
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(b), "package model\n")
+		assert.Contains(t, string(b), "\"time\"")
+		assert.Contains(t, string(b), "type UserProfile struct")
+	}
+	b, err = ioutil.ReadFile(filepath.Join(dir, "shop_order.go"))
+	if assert.NoError(t, err) {
+		assert.NotContains(t, string(b), "import")
+		assert.Contains(t, string(b), "type ShopOrder struct")
+		assert.NotContains(t, string(b), "UserProfile")
+	}
+
+	// overwrite existing files
+	if assert.NoError(t, ParseSqlToFiles("CREATE TABLE ShopOrder (no int NOT NULL);", dir)) {
+		b, err = ioutil.ReadFile(filepath.Join(dir, "shop_order.go"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(b), "No int")
+			assert.NotContains(t, string(b), "ID int")
+		}
+	}
+}
+
+func TestToSnake(t *testing.T) {
+	assert.Equal(t, "user_profile", toSnake("user_profile"))
+	assert.Equal(t, "user_profile", toSnake("UserProfile"))
+	assert.Equal(t, "user_id", toSnake("UserID"))
+	assert.Equal(t, "http_log", toSnake("HTTPLog"))
+	assert.Equal(t, "log2_item", toSnake("Log2Item"))
+	assert.Equal(t, "order_item", toSnake("order-item"))
+}
+
+func TestParseSqlToWriteFormat(t *testing.T) {
+	buf := bytes.Buffer{}
+	err := ParseSqlToWrite("CREATE TABLE a (id int NOT NULL);\nCREATE TABLE b (t datetime NOT NULL);", &buf)
+	if !assert.NoError(t, err) {
+		return
+	}
+	code := buf.String()
+	assert.Contains(t, code, "}\n\ntype B struct {")
+	assert.NotContains(t, code, "\n\n\n")
+	assert.True(t, strings.HasSuffix(code, "}\n"))
+
+	code = string(mustFormat(t, "package model\n\nimport (\n\t\"time\"\n\t\"database/sql\"\n\t\"gopkg.in/guregu/null.v4\"\n)\n\ntype A struct {\n\tT  time.Time\n\tN null.String\n}\n"))
+	assert.NotContains(t, code, "database/sql")
+	assert.Contains(t, code, "import (\n\t\"time\"\n\n\t\"gopkg.in/guregu/null.v4\"\n)")
+
+	src := []byte("package model\n\ntype A struct {\n")
+	out, err := formatCode(src)
+	assert.Error(t, err)
+	assert.Equal(t, src, out)
+}
+
+func mustFormat(t *testing.T, src string) []byte {
+	out, err := formatCode([]byte(src))
+	assert.NoError(t, err)
+	return out
+}
+
+func TestImportName(t *testing.T) {
+	assert.Equal(t, "time", importName("time"))
+	assert.Equal(t, "sql", importName("database/sql"))
+	assert.Equal(t, "gorm", importName("gorm.io/gorm"))
+	assert.Equal(t, "null", importName("gopkg.in/guregu/null.v4"))
+	assert.Equal(t, "redis", importName("github.com/go-redis/redis/v8"))
+	assert.Equal(t, "decimal", importName("github.com/shopspring/decimal"))
+}
+
+func TestParseSqlValidateTag(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL AUTO_INCREMENT,
+  email varchar(255) NOT NULL,
+  nick varchar(32) NULL,
+  code char(8) NOT NULL DEFAULT '',
+  bio text NOT NULL,
+  age int NOT NULL,
+  score int NULL,
+  active tinyint(1) NOT NULL,
+  born date NOT NULL,
+  PRIMARY KEY (id)
+);`
+	data, err := ParseSql(sql, WithValidateTag(), WithNullStyle(NullInPointer), WithTinyIntBool())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "`gorm:\"column:id;primaryKey;autoIncrement\"`")
+	assert.Contains(t, code, `gorm:"column:email;NOT NULL" validate:"required,max=255"`)
+	assert.Contains(t, code, `gorm:"column:nick" validate:"omitempty,max=32"`)
+	assert.Contains(t, code, `gorm:"column:code;NOT NULL" validate:"max=8"`)
+	assert.Contains(t, code, `gorm:"column:bio;NOT NULL" validate:"required"`)
+	// zero values are valid for numbers and bool
+	assert.Contains(t, code, "`gorm:\"column:age;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:score\"`")
+	assert.Contains(t, code, "Active bool ")
+	assert.Contains(t, code, "`gorm:\"column:active;NOT NULL\"`")
+	assert.Contains(t, code, `gorm:"column:born;NOT NULL" validate:"required"`)
+
+	data, err = ParseSql(sql, WithValidateTag(), WithValidateDefaultRequired())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `gorm:"column:code;NOT NULL" validate:"required,max=8"`)
+	}
+}
+
+func TestParseSqlDefaultTags(t *testing.T) {
+	sql := `CREATE TABLE t (
+  status tinyint NOT NULL DEFAULT 1,
+  level int NOT NULL DEFAULT '2',
+  delta int NOT NULL DEFAULT -1,
+  name varchar(50) NOT NULL DEFAULT '',
+  title varchar(50) NOT NULL DEFAULT 'a;b',
+  memo varchar(50) DEFAULT NULL,
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+	data, err := ParseSql(sql, WithDefaultTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `gorm:"column:status;default:1;NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:level;default:2;NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:delta;default:-1;NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:name;default:'';NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:title;default:'a\\;b';NOT NULL"`)
+	assert.Contains(t, code, "`gorm:\"column:memo\"`")
+	assert.Contains(t, code, `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"`)
+
+	data, err = ParseSql(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `gorm:"column:name;NOT NULL"`)
+	}
+}
+
+func TestParseSqlStringDefaults(t *testing.T) {
+	sql := `CREATE TABLE t (
+  name varchar(50) NOT NULL DEFAULT 'O''Brien',
+  note varchar(50) NOT NULL DEFAULT 'line1\nline2',
+  path varchar(50) NOT NULL DEFAULT 'C:\\dir\'s',
+  quote varchar(50) NOT NULL DEFAULT 'say "hi" ok',
+  quoted varchar(50) NOT NULL DEFAULT '"hi"'
+);`
+	data, err := ParseSql(sql, WithDefaultTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `gorm:"column:name;default:'O'Brien';NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:note;default:'line1\nline2';NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:path;default:'C:\\dir's';NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:quote;default:'say \"hi\" ok';NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:quoted;NOT NULL"`)
+	assert.Equal(t, []Warning{{Table: "t", Column: "quoted", Message: `default value "\"hi\"" is dropped from gorm tag, its quotes at the ends are trimmed by gorm`}}, data.Warnings)
+
+	sql = `CREATE TABLE t (
+  name text NOT NULL DEFAULT 'O''Brien',
+  path text NOT NULL DEFAULT 'C:\dir',
+  note text NOT NULL DEFAULT E'it\'s\n'
+);`
+	data, err = ParseSql(sql, WithDialect(DialectPostgres), WithDefaultTags(), WithORM(ORMXorm))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `xorm:"not null default 'O''Brien' 'name'"`)
+		assert.Contains(t, data.StructCode[0], `xorm:"not null default 'C:\\dir' 'path'"`)
+		assert.Contains(t, data.StructCode[0], `xorm:"not null default 'it''s\n' 'note'"`)
+	}
+}
+
+func TestParseSqlSpatialTypes(t *testing.T) {
+	sql := `CREATE TABLE places (
+  id int PRIMARY KEY,
+  loc POINT NOT NULL SRID 4326,
+  area polygon,
+  SPATIAL KEY idx_loc (loc)
+);`
+	data, err := ParseSql(sql, WithGormType(), WithIndexTags())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Loc  []byte `gorm:\"column:loc;type:point;index:idx_loc,class:SPATIAL;NOT NULL\"`")
+		assert.Contains(t, data.StructCode[0], "Area []byte `gorm:\"column:area;type:polygon\"`")
+	}
+
+	sql += `
+CREATE SPATIAL INDEX idx_area ON places (area);
+ALTER TABLE places ADD SPATIAL INDEX idx_area2 (area);`
+	tables, err := ParseTables(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.Equal(t, []IndexInfo{
+			{Name: "idx_loc", Columns: []string{"loc"}, Spatial: true},
+			{Name: "idx_area", Columns: []string{"area"}, Spatial: true},
+			{Name: "idx_area2", Columns: []string{"area"}, Spatial: true},
+		}, tables[0].Indexes[1:])
+	}
+	data, err = ParseSql(sql, WithSpatialType("github.com/twpayne/go-geom/encoding/ewkb.Point"), WithNullStyle(NullInPointer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Loc  ewkb.Point ")
+		assert.Contains(t, data.StructCode[0], "Area ewkb.Point ")
+		assert.Equal(t, []string{"github.com/twpayne/go-geom/encoding/ewkb"}, data.ImportPath)
+	}
+
+	// point of postgres isn't a geometry of PostGIS
+	data, err = ParseSql(`CREATE TABLE places (id int PRIMARY KEY, loc geometry(Point,4326) NOT NULL, p point NOT NULL);`,
+		WithDialect(DialectPostgres), WithSpatialType("github.com/twpayne/go-geom/encoding/ewkb.Point"))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Loc ewkb.Point ")
+		assert.Contains(t, data.StructCode[0], "P   string ")
+	}
+}
+
+func TestParseSqlUnknownTypeFallback(t *testing.T) {
+	sql := `CREATE TABLE t (id int PRIMARY KEY, m mood NOT NULL, n mood NULL, tags mood[], g geometry(Point,4326));`
+	tests := []struct {
+		fallback string
+		expected []string
+	}{
+		{"", []string{"M    string ", "N    *string ", "Tags []string ", "G    []byte "}},
+		{"interface{}", []string{"M    interface{} ", "N    interface{} ", "Tags []interface{} ", "G    []byte "}},
+		{"encoding/json.RawMessage", []string{"M    json.RawMessage ", "N    *json.RawMessage ", "Tags []json.RawMessage "}},
+	}
+	for _, test := range tests {
+		opts := []Option{WithDialect(DialectPostgres), WithNullStyle(NullInPointer)}
+		if test.fallback != "" {
+			opts = append(opts, WithUnknownTypeFallback(test.fallback))
+		}
+		data, err := ParseSql(sql, opts...)
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+			continue
+		}
+		for _, s := range test.expected {
+			assert.Contains(t, data.StructCode[0], s, test.fallback)
+		}
+		// a warning of each column of mood
+		assert.Equal(t, 3, len(data.Warnings), test.fallback)
+	}
+}
+
+func TestParseSqlTextBlobTypes(t *testing.T) {
+	sql := `CREATE TABLE posts (
+  a tinytext NOT NULL,
+  b text NOT NULL,
+  c mediumtext NOT NULL,
+  d longtext NOT NULL,
+  e tinyblob NOT NULL,
+  f blob NOT NULL,
+  g mediumblob NOT NULL,
+  h longblob NULL,
+  i varbinary(255) NULL,
+  j binary(4) NOT NULL
+);`
+	data, err := ParseSql(sql, WithGormType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	for _, field := range []string{
+		"A string `gorm:\"column:a;type:tinytext;NOT NULL\"`",
+		"B string `gorm:\"column:b;type:text;NOT NULL\"`",
+		"C string `gorm:\"column:c;type:mediumtext;NOT NULL\"`",
+		"D string `gorm:\"column:d;type:longtext;NOT NULL\"`",
+		"E []byte `gorm:\"column:e;type:tinyblob;NOT NULL\"`",
+		"F []byte `gorm:\"column:f;type:blob;NOT NULL\"`",
+		"G []byte `gorm:\"column:g;type:mediumblob;NOT NULL\"`",
+		"H []byte `gorm:\"column:h;type:longblob\"`",
+		"I []byte `gorm:\"column:i;type:varbinary(255)\"`",
+		"J []byte `gorm:\"column:j;type:binary(4);NOT NULL\"`",
+	} {
+		assert.Contains(t, code, field)
+	}
+	assert.Equal(t, []string{}, data.ImportPath)
+}
+
+func TestParseSqlSizeTag(t *testing.T) {
+	sql := `CREATE TABLE posts (
+  id int PRIMARY KEY,
+  title varchar(255) NOT NULL,
+  lang char(2),
+  body longtext,
+  summary text,
+  views int
+);`
+	data, err := ParseSql(sql, WithSizeTag())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `gorm:"column:title;size:255;NOT NULL"`)
+	assert.Contains(t, code, `gorm:"column:lang;size:2"`)
+	assert.Contains(t, code, `gorm:"column:body;type:longtext"`)
+	assert.Contains(t, code, `gorm:"column:summary;type:text"`)
+	assert.Contains(t, code, `gorm:"column:views"`)
+
+	data, err = ParseSql(sql, WithSizeTag(), WithGormType())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `gorm:"column:title;type:varchar(255);NOT NULL"`)
+	}
+}
+
+func TestParseSqlSingularStruct(t *testing.T) {
+	tests := []struct {
+		table    string
+		name     string
+		nameFunc bool
+	}{
+		{"users", "User", false},
+		{"categories", "Category", false},
+		{"people", "Person", false},
+		{"user_statuses", "UserStatus", false},
+		{"user", "User", true},
+		{"t_orders", "Order", true},
+	}
+	for _, test := range tests {
+		sql := "CREATE TABLE " + test.table + " (id int PRIMARY KEY);"
+		data, err := ParseSql(sql, WithSingularStruct(), WithTablePrefix("t_"))
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+			continue
+		}
+		code := data.StructCode[0]
+		assert.Contains(t, code, "type "+test.name+" struct")
+		fn := "func (m *" + test.name + ") TableName() string {\n\treturn \"" + test.table + "\"\n}"
+		if test.nameFunc {
+			assert.Contains(t, code, fn)
+		} else {
+			assert.NotContains(t, code, "TableName()")
+		}
+	}
+
+	data, err := ParseSql("CREATE TABLE people (id int PRIMARY KEY);", WithSingularStruct(), WithForceTableName())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "return \"people\"")
+	}
+}
+
+func TestParseSqlTableSuffix(t *testing.T) {
+	tests := []struct {
+		table string
+		name  string
+	}{
+		{"t_user_tbl", "User"},
+		{"t_order_items_tbl", "OrderItem"},
+		{"user_tbl", "User"},
+		{"t_tbl", "Tbl"},
+		{"t_tags", "Tag"},
+	}
+	for _, test := range tests {
+		sql := "CREATE TABLE " + test.table + " (id int PRIMARY KEY);"
+		data, err := ParseSql(sql, WithTablePrefix("t_"), WithTableSuffix("_tbl"), WithSingularStruct())
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+			continue
+		}
+		code := data.StructCode[0]
+		assert.Contains(t, code, "type "+test.name+" struct")
+		assert.Contains(t, code, "func (m *"+test.name+") TableName() string {\n\treturn \""+test.table+"\"\n}")
+	}
+}
+
+func TestParseSqlTableNameConst(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE t_order_items (id int PRIMARY KEY);`
+	data, err := ParseSql(sql, WithTablePrefix("t_"), WithSingularStruct(), WithTableNameConst())
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `const UserTableName = "users"`)
+		assert.Contains(t, data.StructCode[1], `const OrderItemTableName = "t_order_items"`)
+		assert.Contains(t, data.StructCode[1], "func (m *OrderItem) TableName() string {\n\treturn OrderItemTableName\n}")
+	}
+}
+
+func TestParseSqlCompositePrimaryKey(t *testing.T) {
+	sql := `CREATE TABLE tenant_users (
+  tenant_id bigint NOT NULL,
+  user_id bigint NOT NULL,
+  role varchar(16) NOT NULL,
+  PRIMARY KEY (tenant_id, user_id)
+);
+CREATE TABLE user_permissions (
+  tenant_id int NOT NULL,
+  UserID int NOT NULL,
+  permission varchar(32) NOT NULL,
+  granted tinyint NOT NULL,
+  PRIMARY KEY (permission, tenant_id, UserID)
+);`
+	data, err := ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, `gorm:"column:tenant_id;primaryKey"`)
+	assert.Contains(t, code, `gorm:"column:user_id;primaryKey"`)
+	assert.Contains(t, code, `gorm:"column:role;NOT NULL"`)
+	code = data.StructCode[1]
+	assert.Contains(t, code, `gorm:"column:tenant_id;primaryKey"`)
+	assert.Contains(t, code, `gorm:"column:UserID;primaryKey"`)
+	assert.Contains(t, code, `gorm:"column:permission;primaryKey"`)
+	assert.Contains(t, code, `gorm:"column:granted;NOT NULL"`)
+
+	tables, err := ParseTables(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(tables)) {
+		assert.Equal(t, []IndexInfo{{Columns: []string{"permission", "tenant_id", "UserID"}, Primary: true}}, tables[1].Indexes)
+	}
+}
+
+func TestParseSqlAutoIncrement(t *testing.T) {
+	sql := `CREATE TABLE events (
+  seq bigint unsigned NOT NULL AUTO_INCREMENT,
+  name varchar(32) NOT NULL,
+  PRIMARY KEY(seq)
+);
+CREATE TABLE logs (
+  seq bigint unsigned NOT NULL AUTO_INCREMENT,
+  message text,
+  KEY(seq)
+);`
+	data, err := ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "Seq  uint   `gorm:\"column:seq;primaryKey;autoIncrement\"`")
+	// AUTO_INCREMENT column is the primary key without PRIMARY KEY
+	assert.Contains(t, data.StructCode[1], "uint   `gorm:\"column:seq;primaryKey;autoIncrement\"`")
+	assert.NotContains(t, data.StructCode[1], "NOT NULL")
+
+	// signed integers of auto increment are unsigned
+	sql = `CREATE TABLE events (seq int NOT NULL AUTO_INCREMENT PRIMARY KEY);
+CREATE TABLE codes (
+  id int AUTO_INCREMENT,
+  code varchar(10) NOT NULL,
+  PRIMARY KEY(code),
+  KEY(id)
+);`
+	data, err = ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "Seq uint `gorm:\"column:seq;primaryKey;autoIncrement\"`")
+	// the declared primary key wins
+	assert.Contains(t, data.StructCode[1], "ID   uint   `gorm:\"column:id;autoIncrement\"`")
+	assert.Contains(t, data.StructCode[1], "Code string `gorm:\"column:code;primaryKey\"`")
+}
+
+func TestParseSqlPrimaryKeyType(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  tenant_id int unsigned NOT NULL
+);
+CREATE TABLE codes (code varchar(8) PRIMARY KEY);`
+	data, err := ParseSql(sql, WithPrimaryKeyType("uint"), WithUnsignedTypes())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "ID       uint   `")
+	assert.Contains(t, data.StructCode[0], "TenantID uint32 `")
+	assert.Contains(t, data.StructCode[1], "Code string `")
+
+	// uint by default, empty keeps the type of column
+	data, err = ParseSql(sql, WithUnsignedTypes())
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "ID       uint   `")
+	}
+	data, err = ParseSql(sql, WithUnsignedTypes(), WithPrimaryKeyType(""))
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "ID       uint32 `")
+	}
+
+	data, err = ParseSql("CREATE TABLE posts (id serial PRIMARY KEY);", WithPrimaryKeyType("int64"), WithDialect(DialectPostgres))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "ID int64 `")
+	}
+}
+
+func TestParseSqlForeignKeyType(t *testing.T) {
+	sql := `CREATE TABLE users (id bigint AUTO_INCREMENT PRIMARY KEY);
+CREATE TABLE orders (
+  id int AUTO_INCREMENT PRIMARY KEY,
+  user_id bigint NOT NULL,
+  buyer_id bigint NULL,
+  FOREIGN KEY (user_id) REFERENCES users(id),
+  FOREIGN KEY (buyer_id) REFERENCES users(id)
+);`
+	data, err := ParseSql(sql, WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "ID uint `")
+	assert.Contains(t, data.StructCode[1], "UserID  uint  `")
+	assert.Contains(t, data.StructCode[1], "BuyerID *uint `")
+
+	// unsigned like the key when the key keeps the type of column
+	data, err = ParseSql(sql, WithUnsignedTypes(), WithPrimaryKeyType(""))
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "ID uint64 `")
+		assert.Contains(t, data.StructCode[1], "UserID  uint64        `")
+	}
+}
+
+func TestParseSqlUUIDType(t *testing.T) {
+	sql := `CREATE TABLE orders (
+  id binary(16) NOT NULL PRIMARY KEY,
+  user_id char(36) NOT NULL,
+  coupon_id binary(16) NULL,
+  token binary(16) NOT NULL,
+  code char(16) NOT NULL
+);`
+	data, err := ParseSql(sql, WithUUIDType("github.com/google/uuid.UUID"), WithGormType(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "uuid.UUID  `gorm:\"column:id;type:binary(16);primaryKey\"`")
+	assert.Contains(t, code, "uuid.UUID  `gorm:\"column:user_id;type:char(36);NOT NULL\"`")
+	assert.Contains(t, code, "*uuid.UUID `gorm:\"column:coupon_id;type:binary(16)\"`")
+	assert.Contains(t, code, "uuid.UUID  `gorm:\"column:token;type:binary(16);NOT NULL\"`")
+	assert.Contains(t, code, "string     `gorm:\"column:code;type:char(16);NOT NULL\"`")
+	assert.Equal(t, []string{"github.com/google/uuid"}, data.ImportPath)
+
+	data, err = ParseSql(sql, WithUUIDType("github.com/google/uuid.UUID", "id", "*_ID"))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		code = data.StructCode[0]
+		assert.Contains(t, code, "CouponID uuid.UUID")
+		assert.Contains(t, code, "Token    []byte")
+	}
+
+	data, err = ParseSql(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.NotContains(t, data.StructCode[0], "uuid")
+	}
+}
+
+func TestParseSqlBsonTag(t *testing.T) {
+	sql := `CREATE TABLE users (
+  u_id bigint NOT NULL PRIMARY KEY,
+  u_name varchar(32) NOT NULL
+);`
+	data, err := ParseSql(sql, WithColumnPrefix("u_"), WithBsonTag())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:u_id;primaryKey\" bson:\"id\"`")
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:u_name;NOT NULL\" bson:\"name\"`")
+	}
+	data, err = ParseSql(sql, WithJsonTag(), WithBsonTag())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:u_id;primaryKey\" json:\"u_id\" bson:\"u_id\"`")
+	}
+}
+
+func TestParseSqlDBTag(t *testing.T) {
+	sql := `CREATE TABLE users (
+  u_id bigint NOT NULL PRIMARY KEY,
+  NickName varchar(32) NULL
+);`
+	data, err := ParseSql(sql, WithColumnPrefix("u_"), WithJsonTag(), WithJsonTagStyle(JsonSnakeCase), WithDBTag())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:u_id;primaryKey\" json:\"id\" db:\"u_id\"`")
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:NickName\" json:\"nick_name\" db:\"NickName\"`")
+	}
+}
+
+func TestParseSqlCharset(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL PRIMARY KEY,
+  name varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  code varchar(8) COLLATE latin1_bin,
+  bio text,
+  avatar blob
+) DEFAULT CHARSET=latin1;
+CREATE TABLE posts (
+  id bigint NOT NULL PRIMARY KEY,
+  title varchar(64)
+);`
+	data, err := ParseSql(sql, WithGormType(), WithCharset("utf8mb4"), WithCollation("utf8mb4_bin"))
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		users, posts := data.StructCode[0], data.StructCode[1]
+		assert.Contains(t, users, `gorm:"column:id;type:bigint(20);primaryKey"`)
+		assert.Contains(t, users, `gorm:"column:name;type:varchar(255);charset:utf8mb4;collation:utf8mb4_general_ci;NOT NULL"`)
+		assert.Contains(t, users, `gorm:"column:code;type:varchar(8);collation:latin1_bin"`)
+		assert.Contains(t, users, `gorm:"column:bio;type:text;charset:latin1"`)
+		assert.Contains(t, users, `gorm:"column:avatar;type:blob"`)
+		assert.Contains(t, posts, `gorm:"column:title;type:varchar(64);charset:utf8mb4;collation:utf8mb4_bin"`)
+	}
+
+	data, err = ParseSql(sql, WithCharset("utf8mb4"))
+	if assert.NoError(t, err) {
+		assert.NotContains(t, data.StructCode[1], "charset")
+	}
+}
+
+func TestParseSqlJsonTagStyle(t *testing.T) {
+	sql := `CREATE TABLE users (
+  user_id bigint NOT NULL PRIMARY KEY,
+  NickName varchar(32) NULL,
+  created_at datetime NOT NULL
+);`
+	tests := []struct {
+		opts     []Option
+		expected []string
+	}{
+		{
+			[]Option{WithJsonTag()},
+			[]string{`json:"user_id"`, `json:"NickName"`, `json:"created_at"`},
+		},
+		{
+			[]Option{WithJsonTag(), WithJsonTagStyle(JsonCamelCase)},
+			[]string{`json:"userId"`, `json:"nickName"`, `json:"createdAt"`},
+		},
+		{
+			[]Option{WithJsonTag(), WithJsonTagStyle(JsonSnakeCase), WithJsonOmitEmpty()},
+			[]string{`json:"user_id"`, `json:"nick_name,omitempty"`, `json:"created_at"`},
+		},
+	}
+	for _, test := range tests {
+		data, err := ParseSql(sql, test.opts...)
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+			continue
+		}
+		for _, s := range test.expected {
+			assert.Contains(t, data.StructCode[0], s)
+		}
+	}
+}
+
+func TestLowerCamel(t *testing.T) {
+	for s, expected := range map[string]string{
+		"user_id":  "userId",
+		"NickName": "nickName",
+		"ID":       "id",
+		"USER_ID":  "userId",
+		"_a__b":    "aB",
+		"ip_v4":    "ipV4",
+	} {
+		assert.Equal(t, expected, lowerCamel(s), s)
+	}
+}
+
+func TestParseSqlToWriteHeader(t *testing.T) {
+	sql := "CREATE TABLE users (id int PRIMARY KEY);"
+	buf := bytes.Buffer{}
+	if assert.NoError(t, ParseSqlToWrite(sql, &buf, WithPackage("model"))) {
+		assert.True(t, strings.HasPrefix(buf.String(), "// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.\n\npackage model\n"))
+	}
+	buf.Reset()
+	if assert.NoError(t, ParseSqlToWrite(sql, &buf, WithSource("schema.sql"))) {
+		assert.True(t, strings.HasPrefix(buf.String(), "// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.\n// source: schema.sql\n\npackage"))
+	}
+}
+
+func TestParseSqlFileHeader(t *testing.T) {
+	sql := "CREATE TABLE users (id int PRIMARY KEY);"
+	buf := bytes.Buffer{}
+	err := ParseSqlToWrite(sql, &buf, WithSource("schema.sql"), WithFileHeader("//go:build mysql"), WithFileHeader("// +build mysql"))
+	if assert.NoError(t, err) {
+		assert.True(t, strings.HasPrefix(buf.String(), `// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+// source: schema.sql
+
+//go:build mysql
+// +build mysql
+
+package model
+`))
+	}
+}
+
+func TestParseSqlTemporalTypes(t *testing.T) {
+	sql := `CREATE TABLE events (
+  a date NOT NULL,
+  b datetime NOT NULL,
+  c timestamp NOT NULL,
+  d time NOT NULL,
+  e year NOT NULL,
+  f date NULL,
+  g time NULL,
+  h year NULL
+);`
+	data, err := ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "A time.Time ")
+	assert.Contains(t, code, "B time.Time ")
+	assert.Contains(t, code, "C time.Time ")
+	assert.Contains(t, code, "D string ")
+	assert.Contains(t, code, "E int16 ")
+	assert.Contains(t, code, "F sql.NullTime ")
+	assert.Contains(t, code, "G sql.NullString ")
+	assert.Contains(t, code, "H *int16 ")
+
+	data, err = ParseSql(sql, WithTimeAsDuration(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code = data.StructCode[0]
+	assert.Contains(t, code, "D time.Duration ")
+	assert.Contains(t, code, "F *time.Time ")
+	assert.Contains(t, code, "G *time.Duration ")
+	assert.Contains(t, code, "H *int16 ")
+	assert.Equal(t, []string{"time"}, data.ImportPath)
+}
+
+func TestParseSqlTinyIntBool(t *testing.T) {
+	sql := `CREATE TABLE users (
+  active tinyint(1) NOT NULL DEFAULT 1,
+  verified tinyint(1) NULL,
+  deleted TINYINT(1) unsigned NOT NULL,
+  level tinyint(4) NOT NULL,
+  status tinyint NOT NULL
+);`
+	data, err := ParseSql(sql, WithTinyIntBool())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "Active   bool")
+	assert.Contains(t, code, "Verified sql.NullBool")
+	assert.Contains(t, code, "Deleted  bool")
+	assert.Contains(t, code, "Level    int")
+	assert.Contains(t, code, "Status   int")
+
+	data, err = ParseSql(sql, WithTinyIntBool(), WithNullStyle(NullInPointer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Verified *bool")
+	}
+	data, err = ParseSql(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.NotContains(t, data.StructCode[0], "bool")
+	}
+}
+
+func TestParseSqlExcludeColumns(t *testing.T) {
+	sql := `CREATE TABLE users (
+  u_id bigint NOT NULL PRIMARY KEY,
+  u_name varchar(32) NOT NULL,
+  _rowversion bigint NOT NULL,
+  u_etl_batch_id int NULL,
+  u_ETL_time datetime NULL
+);`
+	data, err := ParseSql(sql, WithColumnPrefix("u_"), WithExcludeColumns("_rowversion", "etl_*"))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "column:u_id")
+	assert.Contains(t, code, "column:u_name")
+	assert.NotContains(t, code, "rowversion")
+	assert.NotContains(t, code, "etl_")
+	assert.NotContains(t, code, "ETL_")
+	assert.Empty(t, data.ImportPath)
+
+	data, err = ParseSql(sql, WithColumnPrefix("u_"), WithExcludeColumns("id"))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.NotContains(t, data.StructCode[0], "column:u_id")
+	}
+}
+
+func TestParseSqlGormModel(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  name varchar(32) NOT NULL,
+  created_at datetime NULL,
+  updated_at datetime NULL,
+  deleted_at datetime NULL
+);
+CREATE TABLE posts (
+  id int NOT NULL PRIMARY KEY,
+  created_at datetime NOT NULL,
+  updated_at datetime NOT NULL
+);
+CREATE TABLE logs (
+  id int NOT NULL PRIMARY KEY,
+  created_at datetime NOT NULL,
+  updated_at datetime NOT NULL,
+  deleted_at int NULL
+);`
+	data, err := ParseSql(sql, WithGormModel())
+	if !assert.NoError(t, err) || !assert.Equal(t, 3, len(data.StructCode)) {
+		return
+	}
+	assert.Equal(t, "type Users struct {\n"+
+		"\tgorm.Model\n"+
+		"\tName string `gorm:\"column:name;NOT NULL\"`\n"+
+		"}\n", data.StructCode[0])
+	assert.Contains(t, data.StructCode[1], "ID        int")
+	assert.Contains(t, data.StructCode[1], "CreatedAt time.Time")
+	assert.NotContains(t, data.StructCode[1], "gorm.Model")
+	assert.NotContains(t, data.StructCode[2], "gorm.Model")
+	assert.Equal(t, []string{"database/sql", "gorm.io/gorm", "time"}, data.ImportPath)
+}
+
+func TestParseSqlNotNullType(t *testing.T) {
+	sql := `CREATE TABLE t (
+  id int NULL,
+  col int NOT NULL DEFAULT 0,
+  amount decimal(10,2) NOT NULL DEFAULT 0,
+  uid char(36) NULL NOT NULL,
+  flag tinyint(1) NOT NULL DEFAULT 0,
+  num int unsigned NOT NULL DEFAULT 0,
+  color enum('red','blue') NOT NULL DEFAULT 'red',
+  PRIMARY KEY (id)
+);`
+	options := [][]Option{
+		{},
+		{WithNullStyle(NullInSql)},
+		{WithNullStyle(NullInPointer)},
+		{WithNoNullType()},
+		{
+			WithNullStyle(NullInPointer), WithTypeMapping(map[string]string{"int": "int32"}),
+			WithDecimalType("github.com/shopspring/decimal.Decimal"), WithUUIDType("github.com/google/uuid.UUID"),
+			WithTinyIntBool(), WithUnsignedTypes(), WithEnumConstants(),
+		},
+	}
+	for _, opts := range options {
+		tables, err := ParseTables(sql, opts...)
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(tables)) {
+			continue
+		}
+		for _, col := range tables[0].Columns {
+			assert.False(t, col.Nullable, col.Name)
+		}
+		data, err := ParseSql(sql, opts...)
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+			continue
+		}
+		fields := strings.Split(data.StructCode[0], "func")[0]
+		assert.NotContains(t, fields, "sql.Null")
+		assert.NotContains(t, fields, "*")
+	}
+}
+
+func TestParseSqlYamlTag(t *testing.T) {
+	sql := `CREATE TABLE configs (
+  c_key varchar(32) NOT NULL PRIMARY KEY,
+  c_value text NULL
+);`
+	data, err := ParseSql(sql, WithColumnPrefix("c_"), WithJsonTag(), WithYamlTag(), WithBsonTag(), WithJsonOmitEmpty())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:c_key;primaryKey\" json:\"key\" yaml:\"key\" bson:\"key\"`")
+		assert.Contains(t, data.StructCode[0], "`gorm:\"column:c_value\" json:\"value,omitempty\" yaml:\"value\" bson:\"value\"`")
+	}
+}
+
+func TestParseSqlAnnotations(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL PRIMARY KEY,
+  email varchar(255) NULL COMMENT 'login @gotype:github.com/me/types.Email @json:emailAddr',
+  secret varchar(64) NOT NULL COMMENT '@json:-',
+  name varchar(32) NOT NULL COMMENT '@gorm:column:name;uniqueIndex full name',
+  etl_batch int NOT NULL COMMENT '@skip'
+);`
+	data, err := ParseSql(sql, WithAnnotations(), WithJsonTag(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "Email  *types.Email `gorm:\"column:email\" json:\"emailAddr\"` // login\n")
+	assert.Contains(t, code, "`gorm:\"column:secret;NOT NULL\" json:\"-\"`\n")
+	assert.Contains(t, code, "`gorm:\"column:name;uniqueIndex\" json:\"name\"` // full name\n")
+	assert.NotContains(t, code, "etl_batch")
+	assert.Equal(t, []string{"github.com/me/types"}, data.ImportPath)
+
+	data, err = ParseSql(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "@skip")
+	}
+}
+
+func TestParseSqlSetAndBit(t *testing.T) {
+	sql := `CREATE TABLE flags (
+  tags set('a','b','c') NOT NULL DEFAULT '',
+  opts SET('x','y') NULL,
+  enabled bit(1) NOT NULL,
+  deleted bit NULL,
+  mask bit(16) NOT NULL,
+  mask2 bit(64) NULL
+);`
+	data, err := ParseSql(sql, WithGormType())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "Tags    StringSet    `gorm:\"column:tags;type:set('a','b','c');default:'';NOT NULL\"`")
+	assert.Contains(t, code, "Opts    StringSet    `gorm:\"column:opts;type:set('x','y')\"`")
+	assert.Contains(t, code, "Enabled bool         `gorm:\"column:enabled;type:bit(1);NOT NULL\"`")
+	assert.Contains(t, code, "Deleted sql.NullBool `gorm:\"column:deleted;type:bit(1)\"`")
+	assert.Contains(t, code, "Mask    []byte       `gorm:\"column:mask;type:bit(16);NOT NULL\"`")
+	assert.Contains(t, code, "Mask2   []byte       `gorm:\"column:mask2;type:bit(64)\"`")
+	// the scanner of SET is declared once
+	assert.Equal(t, 1, strings.Count(code, "type StringSet []string"))
+	assert.Contains(t, code, "func (s *StringSet) Scan(src interface{}) error {")
+	assert.Equal(t, []string{"database/sql", "database/sql/driver", "fmt", "strings"}, data.ImportPath)
+
+	data, err = ParseSql(sql+"\nCREATE TABLE roles (perms set('r','w') NOT NULL);", WithNullStyle(NullInPointer))
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Opts    StringSet")
+		assert.Contains(t, data.StructCode[0], "Deleted *bool")
+		assert.Contains(t, data.StructCode[1], "Perms StringSet")
+		assert.NotContains(t, data.StructCode[1], "type StringSet")
+	}
+}
+
+func TestParseSqlStructNameMap(t *testing.T) {
+	sql := `CREATE TABLE tbl_usr (id int PRIMARY KEY);
+CREATE TABLE tbl_orders (id int PRIMARY KEY, usr_id int REFERENCES TBL_USR(id));`
+	data, err := ParseSql(sql, WithStructNameMap(map[string]string{"TBL_usr": "User"}),
+		WithTablePrefix("tbl_"), WithSingularStruct(), WithAssociations())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "type User struct")
+	assert.Contains(t, data.StructCode[0], "func (m *User) TableName() string {\n\treturn \"tbl_usr\"\n}")
+	assert.Contains(t, data.StructCode[1], "type Order struct")
+	assert.Contains(t, data.StructCode[1], "Usr   User")
+}
+
+func TestParseSqlStructNamePrefix(t *testing.T) {
+	sql := `CREATE TABLE t_users (id int PRIMARY KEY);
+CREATE TABLE t_orders (id int PRIMARY KEY, user_id int REFERENCES t_users(id));
+CREATE TABLE t_items (id int PRIMARY KEY);`
+	data, err := ParseSql(sql, WithStructNamePrefix("PG"), WithTablePrefix("t_"), WithSingularStruct(),
+		WithAssociations(), WithStructNameMap(map[string]string{"t_items": "Item"}))
+	if !assert.NoError(t, err) || !assert.Equal(t, 3, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "type PGUser struct")
+	assert.Contains(t, data.StructCode[0], "func (m *PGUser) TableName() string {\n\treturn \"t_users\"\n}")
+	assert.Contains(t, data.StructCode[1], "User   PGUser")
+	assert.Contains(t, data.StructCode[2], "type Item struct")
+}
+
+func TestParseSqlFieldOrder(t *testing.T) {
+	sql := `CREATE TABLE members (
+  name varchar(32) NOT NULL,
+  user_id int NOT NULL,
+  age int NOT NULL,
+  group_id int NOT NULL,
+  PRIMARY KEY (group_id, user_id)
+);`
+	tests := []struct {
+		order  FieldOrder
+		fields []string
+	}{
+		{OrderDeclaration, []string{"Name", "UserID", "Age", "GroupID"}},
+		{OrderAlphabetical, []string{"Age", "GroupID", "Name", "UserID"}},
+		{OrderPrimaryKeyFirst, []string{"UserID", "GroupID", "Name", "Age"}},
+	}
+	for _, test := range tests {
+		data, err := ParseSql(sql, WithFieldOrder(test.order))
+		if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+			continue
+		}
+		lines := strings.Split(strings.TrimSpace(data.StructCode[0]), "\n")
+		fields := make([]string, 0, len(lines))
+		for _, l := range lines[1 : len(lines)-1] {
+			fields = append(fields, strings.Fields(l)[0])
+		}
+		assert.Equal(t, test.fields, fields, test.order)
+	}
+}
+
+func TestParseSqlJSONDatatype(t *testing.T) {
+	sql := `CREATE TABLE events (
+  id int PRIMARY KEY,
+  payload json NOT NULL,
+  extra json NULL
+);`
+	data, err := ParseSql(sql, WithJSONDatatype(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "Payload datatypes.JSON `gorm:\"column:payload;NOT NULL\"`")
+	assert.Contains(t, data.StructCode[0], "Extra   datatypes.JSON `gorm:\"column:extra\"`")
+	assert.Equal(t, []string{"gorm.io/datatypes"}, data.ImportPath)
+
+	data, err = ParseSql(`CREATE TABLE events (id serial PRIMARY KEY, doc jsonb NULL, tags json[] NULL);`,
+		WithDialect(DialectPostgres), WithJSONDatatype())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Doc  datatypes.JSON")
+		assert.Contains(t, data.StructCode[0], "Tags []string")
+	}
+
+	data, err = ParseSql(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "Payload string")
+	}
+}
+
+func TestParseSqlNullAccessors(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int PRIMARY KEY,
+  email varchar(255) NULL,
+  get_email int NULL,
+  created_at datetime NULL,
+  name varchar(32) NOT NULL
+);`
+	data, err := ParseSql(sql, WithNullAccessors())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "func (m *Users) GetEmailValue() (string, bool) {\n\treturn m.Email.String, m.Email.Valid\n}")
+	assert.Contains(t, code, "func (m *Users) SetEmail(v string) {\n\tm.Email = sql.NullString{String: v, Valid: true}\n}")
+	assert.Contains(t, code, "func (m *Users) GetGetEmail() (int32, bool) {")
+	assert.Contains(t, code, "func (m *Users) GetCreatedAt() (time.Time, bool) {")
+	assert.NotContains(t, code, "GetName")
+	assert.Equal(t, []string{"database/sql", "time"}, data.ImportPath)
+
+	data, err = ParseSql(sql, WithNullAccessors(), WithNullStyle(NullInPointer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		code = data.StructCode[0]
+		assert.Contains(t, code, "func (m *Users) GetCreatedAt() (time.Time, bool) {\n"+
+			"\tif m.CreatedAt == nil {\n\t\tvar v time.Time\n\t\treturn v, false\n\t}\n\treturn *m.CreatedAt, true\n}")
+		assert.Contains(t, code, "func (m *Users) SetCreatedAt(v time.Time) {\n\tm.CreatedAt = &v\n}")
+	}
+}
+
+func TestParseSqlUnexportedFields(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int PRIMARY KEY,
+  http_code int NOT NULL,
+  type varchar(16) NOT NULL,
+  email varchar(255) NULL
+);`
+	data, err := ParseSql(sql, WithUnexportedFields(), WithNullAccessors())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "id       int            `gorm:\"column:id;primaryKey\"`")
+	assert.Contains(t, code, "httpCode int ")
+	assert.Contains(t, code, "type_    string")
+	assert.Contains(t, code, "func (m *Users) GetEmail() (string, bool) {\n\treturn m.email.String, m.email.Valid\n}")
+}
+
+func TestUnexportedName(t *testing.T) {
+	for s, expected := range map[string]string{
+		"ID":       "id",
+		"UserID":   "userID",
+		"HTTPCode": "httpCode",
+		"Name":     "name",
+		"Type":     "type_",
+	} {
+		assert.Equal(t, expected, unexportedName(s))
+	}
+}
+
+func TestParseSqlCheck(t *testing.T) {
+	sql := `CREATE TABLE people (
+  id int PRIMARY KEY,
+  age int CONSTRAINT age_check CHECK (age >= 0),
+  started date,
+  ended date,
+  CONSTRAINT period_check CHECK (started <= ended) NOT ENFORCED,
+  CHECK (
+    ended IS NULL
+    OR ended > '2000-01-01')
+);`
+	tables, err := ParseTables(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.Equal(t, []CheckInfo{
+			{Name: "age_check", Expr: "age >= 0", Columns: []string{"age"}},
+			{Name: "period_check", Expr: "started <= ended", Columns: []string{"started", "ended"}},
+			{Expr: "ended IS NULL OR ended > '2000-01-01'", Columns: []string{"ended"}},
+		}, tables[0].Checks)
+	}
+
+	data, err := ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "// CHECK: started <= ended\ntype People struct {")
+	assert.Contains(t, code, "\t// CHECK: age >= 0\n\tAge ")
+	assert.Contains(t, code, "\t// CHECK: ended IS NULL OR ended > '2000-01-01'\n\tEnded ")
+	assert.NotContains(t, code, "check:")
+
+	data, err = ParseSql(sql, WithGormType())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		code = data.StructCode[0]
+		assert.Contains(t, code, `gorm:"column:age;type:int(11);check:age_check,age >= 0"`)
+		assert.Contains(t, code, `gorm:"column:started;type:date;check:period_check,started <= ended"`)
+		assert.Contains(t, code, `gorm:"column:ended;type:date;check:ended IS NULL OR ended > '2000-01-01'"`)
+	}
+}
+
+func TestParseSqlOmitRedundantColumnTag(t *testing.T) {
+	sql := `CREATE TABLE t (
+  id int PRIMARY KEY,
+  user_id int NOT NULL,
+  http_code int,
+  createdAt int,
+  ip_v4 varchar(15)
+);`
+	data, err := ParseSql(sql, WithOmitRedundantColumnTag())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "`gorm:\"primaryKey\"`")
+	assert.Contains(t, code, "`gorm:\"NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:createdAt\"`")
+	assert.Contains(t, code, "HttpCode  int\n")
+	assert.Contains(t, code, "IPV4      string\n")
+}
+
+func TestParseSqlExplicitColumnTags(t *testing.T) {
+	sql := `CREATE TABLE t (
+  id int PRIMARY KEY,
+  user_id int NOT NULL,
+  http_code int
+);`
+	expected, err := ParseSql(sql)
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, opts := range [][]Option{
+		{WithExplicitColumnTags()},
+		{WithExplicitColumnTags(), WithOmitRedundantColumnTag()},
+		{WithOmitRedundantColumnTag(), WithExplicitColumnTags()},
+	} {
+		data, err := ParseSql(sql, opts...)
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected.StructCode, data.StructCode)
+		}
+	}
+	code := expected.StructCode[0]
+	assert.Contains(t, code, "`gorm:\"column:id;primaryKey\"`")
+	assert.Contains(t, code, "`gorm:\"column:user_id;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:http_code\"`")
+}
+
+func TestGormColumnName(t *testing.T) {
+	for s, expected := range map[string]string{
+		"UserID":     "user_id",
+		"HTTPSURL":   "http_s_url", // HTTP is replaced before HTTPS
+		"IPV4":       "ip_v4",
+		"UTF8Name":   "utf8_name",
+		"OAuth2":     "o_auth2",
+		"A":          "a",
+		"CreatedAt":  "created_at",
+		"SKU1":       "sku1",
+		"User_Name":  "user_name",
+		"APIKeyHash": "api_key_hash",
+	} {
+		assert.Equal(t, expected, gormColumnName(s), s)
+	}
+}
+
+func TestParseSqlRepository(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint PRIMARY KEY AUTO_INCREMENT,
+  name varchar(32) NOT NULL
+);
+CREATE TABLE user_roles (
+  tenant_id int NOT NULL,
+  user_id int NOT NULL,
+  PRIMARY KEY (tenant_id, user_id)
+);
+CREATE TABLE logs (
+  message text
+);`
+	data, err := ParseSql(sql, WithRepository())
+	if !assert.NoError(t, err) || !assert.Equal(t, 3, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "type UsersRepository interface {\n"+
+		"\tCreate(ctx context.Context, m *Users) error\n"+
+		"\tFindByID(ctx context.Context, id uint) (*Users, error)\n"+
+		"\tUpdate(ctx context.Context, m *Users) error\n"+
+		"\tDelete(ctx context.Context, id uint) error\n}")
+	assert.Contains(t, code, "func NewUsersRepository(db *gorm.DB) UsersRepository {\n\treturn &usersRepository{db: db}\n}")
+	assert.Contains(t, code, `r.db.WithContext(ctx).Where("id = ?", id).First(&m).Error`)
+	code = data.StructCode[1]
+	assert.Contains(t, code, "FindByID(ctx context.Context, tenantID int, userID int) (*UserRoles, error)")
+	assert.Contains(t, code, `Where("tenant_id = ? AND user_id = ?", tenantID, userID).Delete(&UserRoles{}).Error`)
+	assert.NotContains(t, data.StructCode[2], "Repository")
+	assert.Equal(t, []string{"context", "gorm.io/gorm"}, data.ImportPath)
+
+	data, err = ParseSql(sql, WithRepository(), WithORM(ORMXorm))
+	if assert.NoError(t, err) && assert.Equal(t, 3, len(data.StructCode)) {
+		assert.NotContains(t, data.StructCode[0], "Repository")
+	}
+}
+
+func TestParseSqlStringMethod(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  email varchar(255) NOT NULL,
+  password varchar(64) NOT NULL,
+  nickname varchar(32) NULL,
+  age int NULL
+);`
+	data, err := ParseSql(sql, WithStringMethod(), WithRedactedColumns("pass*"))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], `
+// String returns the fields of Users for logging
+func (m Users) String() string {
+	b := strings.Builder{}
+	b.WriteString("Users{")
+	fmt.Fprintf(&b, "ID: %v", m.ID)
+	fmt.Fprintf(&b, ", Email: %q", m.Email)
+	b.WriteString(", Password: <redacted>")
+	if m.Nickname.Valid {
+		fmt.Fprintf(&b, ", Nickname: %q", m.Nickname.String)
+	} else {
+		b.WriteString(", Nickname: NULL")
+	}
+	if m.Age.Valid {
+		fmt.Fprintf(&b, ", Age: %v", m.Age.Int32)
+	} else {
+		b.WriteString(", Age: NULL")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+`)
+	assert.Equal(t, []string{"database/sql", "fmt", "strings"}, data.ImportPath)
+
+	data, err = ParseSql(sql, WithStringMethod(), WithNullStyle(NullInPointer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `	fmt.Fprintf(&b, ", Password: %q", m.Password)
+	if m.Nickname != nil {
+		fmt.Fprintf(&b, ", Nickname: %q", *m.Nickname)
+	} else {
+		b.WriteString(", Nickname: NULL")
+	}
+`)
+	}
+
+	// all values are redacted
+	data, err = ParseSql("CREATE TABLE secrets (token varchar(32) NOT NULL);", WithStringMethod(), WithRedactedColumns("token"))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], `b.WriteString("Token: <redacted>")`)
+		assert.Equal(t, []string{"strings"}, data.ImportPath)
+	}
+
+	// String() can't be declared with a field named String
+	data, err = ParseSql("CREATE TABLE words (string varchar(32) NOT NULL);", WithStringMethod())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.NotContains(t, data.StructCode[0], "String()")
+	}
+}
+
+func TestParseSqlReservedWords(t *testing.T) {
+	sql := "CREATE TABLE `order` (\n" +
+		"  `select` int PRIMARY KEY,\n" +
+		"  `order` int NOT NULL,\n" +
+		"  `group` varchar(10),\n" +
+		"  `desc` text,\n" +
+		"  `type` int,\n" +
+		"  KEY `desc` (`group`, `desc`)\n" +
+		");\nCREATE INDEX `order` ON `order` (`order` DESC);"
+	data, err := ParseSql(sql, WithJsonTag(), WithIndexTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "type Order struct {")
+	assert.Contains(t, code, "Select int    `gorm:\"column:select;primaryKey\" json:\"select\"`")
+	assert.Contains(t, code, "Order  int    `gorm:\"column:order;index:order;NOT NULL\" json:\"order\"`")
+	assert.Contains(t, code, "Group  string `gorm:\"column:group;index:desc,priority:1\" json:\"group\"`")
+	assert.Contains(t, code, "Desc   string `gorm:\"column:desc;index:desc,priority:2\" json:\"desc\"`")
+	assert.Contains(t, code, "Type   int    `gorm:\"column:type\" json:\"type\"`")
+	assert.Contains(t, code, `return "order"`)
+
+	for _, d := range []Dialect{DialectPostgres, DialectSQLite} {
+		sql = `CREATE TABLE "order" ("select" int PRIMARY KEY, "order" int, "group" text, "desc" text);`
+		data, err = ParseSql(sql, WithDialect(d))
+		if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+			assert.Contains(t, data.StructCode[0], "Order  int")
+			assert.Contains(t, data.StructCode[0], `gorm:"column:desc"`)
+		}
+	}
+}
+
+func TestParseSqlInvalidIdentifier(t *testing.T) {
+	sql := "CREATE TABLE `2023_logs` (`_` int, `2fa` int, `名字` int);"
+	data, err := ParseSql(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "type Table2023Logs struct {")
+	assert.Contains(t, code, "Column1   int `gorm:\"column:_\"`")
+	assert.Contains(t, code, "Column2Fa int `gorm:\"column:2fa\"`")
+	assert.Contains(t, code, "Column3   int `gorm:\"column:名字\"`")
+}
+
+func TestParseSqlTemplate(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int PRIMARY KEY,
+  email varchar(255) NULL COMMENT 'login email'
+) COMMENT 'all users';`
+	expected, err := ParseSql(sql, WithJsonTag())
+	if !assert.NoError(t, err) {
+		return
+	}
+	data, err := ParseSql(sql, WithJsonTag(), WithTemplate(DefaultTemplate()))
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, data)
+	}
+
+	tmpl := `// {{.TableName}} has {{len .Table.Columns}} columns of {{.RawTableName}}
+type {{.TableName}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} // {{.Comment}}
+{{- end}}
+}
+`
+	data, err = ParseSql(sql, WithTemplate(tmpl))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Equal(t, "// Users has 2 columns of users\ntype Users struct {\n"+
+			"\tID    int            //\n\tEmail sql.NullString // login email\n}\n", data.StructCode[0])
+		assert.Equal(t, []string{"database/sql"}, data.ImportPath)
+	}
+
+	_, err = ParseSql(sql, WithTemplate("{{.Name"))
+	assert.Error(t, err)
+}
+
+func TestParseSqlGeneratedColumns(t *testing.T) {
+	sql := `CREATE TABLE people (
+  id int PRIMARY KEY,
+  first_name varchar(32) NOT NULL,
+  last_name varchar(32) NOT NULL,
+  full_name varchar(65) GENERATED ALWAYS AS (concat(first_name, ' ', last_name)) STORED,
+  ` + "`initial`" + ` char(1) AS (left(first_name, 1)) VIRTUAL NOT NULL COMMENT 'first letter'
+);`
+	tables, err := ParseTables(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.Equal(t, "concat(first_name, ' ', last_name)", tables[0].Columns[3].Generated)
+		assert.True(t, tables[0].Columns[3].Stored)
+		assert.Equal(t, "left(first_name, 1)", tables[0].Columns[4].Generated)
+		assert.False(t, tables[0].Columns[4].Stored)
+	}
+
+	data, err := ParseSql(sql, WithValidateTag())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "\t// GENERATED AS (concat(first_name, ' ', last_name)) STORED\n"+
+		"\tFullName string `gorm:\"column:full_name;->\" validate:\"max=65\"`\n")
+	assert.Contains(t, code, "\t// GENERATED AS (left(first_name, 1)) VIRTUAL\n"+
+		"\tInitial string `gorm:\"column:initial;->;NOT NULL\" validate:\"max=1\"` // first letter\n")
+
+	data, err = ParseSql(sql, WithORM(ORMXorm))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "`xorm:\"<- not null 'initial'\"`")
+	}
+}
+
+func TestParseSqlError(t *testing.T) {
+	_, err := ParseSql("CREATE TABLE a (\n  id int,\n  name varchar(10) NOT NULL DEFAULT,\n  age int\n);")
+	var parseErr *ParseError
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 3, parseErr.Line)
+		assert.Equal(t, 37, parseErr.Column)
+		assert.Equal(t, `syntax error near "age int"`, parseErr.Message)
+		assert.Equal(t, `line 3 column 37: syntax error near "age int"`, err.Error())
+	}
+
+	_, err = ParseSql("CREATE TABLE a (\n  id int COMMENT 'x\n);", WithDialect(DialectPostgres))
+	if assert.ErrorAs(t, err, &parseErr) {
+		assert.Equal(t, 2, parseErr.Line)
+		assert.Equal(t, 0, parseErr.Column)
+		assert.Equal(t, "unterminated string", parseErr.Message)
+	}
+}
+
+func TestParseSqlTruncated(t *testing.T) {
+	for _, sql := range []string{"CREATE TABLE t (", "CREATE TABLE t (\n  id int,\n  price decimal(10, 2)"} {
+		_, err := ParseSql(sql)
+		var parseErr *ParseError
+		assert.ErrorAs(t, err, &parseErr, sql)
+	}
+}
+
+func TestParseSqlEmbeddedGroups(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int PRIMARY KEY,
+  address_id int,
+  address_street varchar(50) NOT NULL,
+  name varchar(20) NOT NULL,
+  address_city varchar(20) NULL,
+  FOREIGN KEY (address_id) REFERENCES addresses (id)
+);
+CREATE TABLE shops (
+  address_street varchar(50) NOT NULL,
+  address_city varchar(20) NULL
+);
+CREATE TABLE offices (
+  address_street varchar(50) NOT NULL
+);`
+	data, err := ParseSql(sql, WithEmbeddedGroups("address"), WithJsonTag(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) || !assert.Equal(t, 3, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "type Address struct {\n"+
+		"\tStreet string  `gorm:\"column:street;NOT NULL\" json:\"street\"`\n"+
+		"\tCity   *string `gorm:\"column:city\" json:\"city\"`\n"+
+		"}")
+	assert.Contains(t, code, "\tAddressID int     `gorm:\"column:address_id\" json:\"address_id\"`\n"+
+		"\tAddress   Address `gorm:\"embedded;embeddedPrefix:address_\" json:\"address\"`\n"+
+		"\tName      string  `gorm:\"column:name;NOT NULL\" json:\"name\"`\n}")
+	assert.NotContains(t, data.StructCode[1], "type Address struct")
+	assert.Contains(t, data.StructCode[1], "Address Address ")
+	assert.Contains(t, data.StructCode[2], "type Address2 struct")
+	assert.Contains(t, data.StructCode[2], "Address Address2 ")
+}
+
+func TestParseSqlColumnsHelper(t *testing.T) {
+	sql := `CREATE TABLE users (
+  u_id int PRIMARY KEY,
+  u_email varchar(50) NOT NULL,
+  u_address_city varchar(20) NOT NULL,
+  u_secret varchar(20) NOT NULL
+);
+CREATE TABLE reports (columns text);`
+	data, err := ParseSql(sql, WithColumnsHelper(), WithColumnPrefix("u_"), WithEmbeddedGroups("address"),
+		WithExcludeColumns("secret"))
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], `var UsersColumns = struct {
+	ID          string
+	Email       string
+	AddressCity string
+}{
+	ID:          "u_id",
+	Email:       "u_email",
+	AddressCity: "u_address_city",
+}`)
+	assert.Contains(t, data.StructCode[0], `func (m Users) Columns() []string {
+	return []string{
+		UsersColumns.ID,
+		UsersColumns.Email,
+		UsersColumns.AddressCity,
+	}
+}`)
+	assert.NotContains(t, data.StructCode[1], "ReportsColumns")
+	assert.Contains(t, data.Warnings, Warning{Table: "reports", Message: "Columns helper is skipped for field Columns"})
+}
+
+func TestParseSqlToWriteLineEnding(t *testing.T) {
+	sql := "CREATE TABLE t (\r\n  id int PRIMARY KEY COMMENT 'a\r\nb'\r\n);"
+	buf := bytes.Buffer{}
+	if assert.NoError(t, ParseSqlToWrite(sql, &buf, WithComments(), WithLineEnding("\r\n"))) {
+		assert.Equal(t, strings.Count(buf.String(), "\n"), strings.Count(buf.String(), "\r\n"))
+		assert.Contains(t, buf.String(), "type T struct {\r\n\t// a\r\n\t// b\r\n")
+	}
+	buf.Reset()
+	if assert.NoError(t, ParseSqlToWrite(sql, &buf, WithComments(), WithTarget(TargetTypeScript))) {
+		assert.NotContains(t, buf.String(), "\r")
+	}
+}
+
+func TestParseSqlPointersForDefaults(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int PRIMARY KEY DEFAULT 1,
+  status tinyint NOT NULL DEFAULT 1,
+  name varchar(20) NOT NULL,
+  nick varchar(20) NULL,
+  updated_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);`
+	data, err := ParseSql(sql, WithPointersForDefaults(), WithGormTimestamps())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "ID        int            `")
+	assert.Contains(t, code, "Status    *int           `gorm:\"column:status;default:1;NOT NULL\"`")
+	assert.Contains(t, code, "Name      string         `")
+	assert.Contains(t, code, "Nick      sql.NullString `")
+	assert.Contains(t, code, "UpdatedAt time.Time      `")
+}
+
+func TestParseSqlDefaultExpressions(t *testing.T) {
+	sql := `CREATE TABLE t (
+  id char(36) NOT NULL DEFAULT uuid() PRIMARY KEY,
+  total int NOT NULL DEFAULT (1 + 1),
+  created_at datetime NOT NULL DEFAULT current_timestamp(),
+  day date NOT NULL DEFAULT CURRENT_DATE COMMENT 'created day'
+);`
+	data, err := ParseSql(sql, WithDefaultTags())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "`gorm:\"column:id;primaryKey;default:uuid()\"`")
+	assert.Contains(t, code, "`gorm:\"column:total;default:(1 + 1);NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL\"`")
+	assert.Contains(t, code, "`gorm:\"column:day;default:CURRENT_DATE;NOT NULL\"` // created day")
+}
+
+func TestParseSqlJSONScanner(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL PRIMARY KEY,
+  profile json NULL COMMENT '@gotype:Profile',
+  email json NULL COMMENT '@gotype:github.com/me/types.Emails'
+);
+CREATE TABLE admins (
+  id bigint NOT NULL PRIMARY KEY,
+  profile json NOT NULL COMMENT '@gotype:Profile',
+  extra json NULL
+);`
+	data, err := ParseSql(sql, WithAnnotations(), WithJSONScanner())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Contains(t, code, "func (v Profile) Value() (driver.Value, error) {\n\treturn json.Marshal(v)\n}")
+	assert.Contains(t, code, "func (v *Profile) Scan(src interface{}) error {")
+	assert.NotContains(t, code, "Emails) Scan")
+	assert.NotContains(t, data.StructCode[1], "Scan(")
+	assert.Equal(t, []string{"database/sql", "database/sql/driver", "encoding/json", "fmt", "github.com/me/types"}, data.ImportPath)
+	if assert.Equal(t, 1, len(data.Warnings)) {
+		assert.Equal(t, "users.email: Scan and Value of types.Emails can't be declared in this package", data.Warnings[0].String())
+	}
+}
+
+func TestParseSqlNoTableName(t *testing.T) {
+	sql := `CREATE TABLE user_infos (id int PRIMARY KEY);
+CREATE TABLE t_order (id int PRIMARY KEY);`
+	data, err := ParseSql(sql, WithNoTableName(), WithForceTableName())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.NotContains(t, data.StructCode[0], "TableName()")
+	assert.NotContains(t, data.StructCode[1], "TableName()")
+	if assert.Equal(t, 1, len(data.Warnings)) {
+		assert.Equal(t, "t_order: TableName method is skipped, gorm takes t_orders as the table of TOrder", data.Warnings[0].String())
+	}
+
+	data, err = ParseSql(sql, WithForceTableName())
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "TableName()")
+		assert.Equal(t, 0, len(data.Warnings))
+	}
+}
+
+func TestParseSqlConstructor(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint PRIMARY KEY AUTO_INCREMENT,
+  status tinyint NOT NULL DEFAULT 1,
+  name varchar(20) NOT NULL DEFAULT 'anon',
+  tag varchar(10) NULL DEFAULT 'x',
+  score double NOT NULL DEFAULT '1.5',
+  zero int NOT NULL DEFAULT 0,
+  uid char(36) NOT NULL DEFAULT (uuid()),
+  updated_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE TABLE logs (id bigint PRIMARY KEY AUTO_INCREMENT);`
+	data, err := ParseSql(sql, WithConstructor())
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], `func NewUsers() *Users {
+	return &Users{
+		Status: 1,
+		Name:   "anon",
+		Tag:    sql.NullString{String: "x", Valid: true},
+		Score:  1.5,
+	}
+}`)
+	assert.Contains(t, data.StructCode[1], "func NewLogs() *Logs {\n\treturn &Logs{}\n}")
+}
+
+func TestParseSqlSchemaInTableName(t *testing.T) {
+	sql := "CREATE TABLE `shop`.`users` (id int PRIMARY KEY);\nCREATE INDEX idx_id ON shop.users (id);"
+	tables, err := ParseTables(sql)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.Equal(t, "users", tables[0].Name)
+		assert.Equal(t, "shop", tables[0].Schema)
+		assert.Equal(t, 2, len(tables[0].Indexes))
+	}
+
+	data, err := ParseSql(sql, WithSingularStruct())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "type User struct {")
+		// gorm names the table of User users
+		assert.NotContains(t, data.StructCode[0], "TableName()")
+	}
+	data, err = ParseSql(sql, WithSingularStruct(), WithSchemaInTableName())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "type User struct {")
+		assert.Contains(t, data.StructCode[0], `return "shop.users"`)
+	}
+
+	data, err = ParseSql(`CREATE TABLE "public"."users" (id int PRIMARY KEY);`,
+		WithDialect(DialectPostgres), WithSchemaInTableName())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "type Users struct {")
+		assert.Contains(t, data.StructCode[0], `return "public.users"`)
+	}
+}
+
+func TestParseSqlToWriteGolden(t *testing.T) {
+	sql := `CREATE TABLE user_accounts (
+  id bigint unsigned NOT NULL AUTO_INCREMENT,
+  email varchar(255) NOT NULL COMMENT 'login name',
+  nickname varchar(32) NULL,
+  balance decimal(10,2) NOT NULL DEFAULT '0.00',
+  is_verified tinyint(1) NOT NULL DEFAULT 0,
+  profile json,
+  avatar blob,
+  shop_id int NOT NULL COMMENT 'owner shop\nof the account',
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_email (email),
+  KEY idx_shop (shop_id)
+) COMMENT 'accounts of users';
+CREATE TABLE tags (name varchar(16) NOT NULL PRIMARY KEY);`
+	var buf bytes.Buffer
+	err := ParseSqlToWrite(sql, &buf, WithJsonTag(), WithIndexTags(), WithDefaultTags(), WithNullStyle(NullInPointer))
+	if !assert.NoError(t, err) {
+		return
+	}
+	golden := filepath.Join("testdata", "struct.golden")
+	if *update {
+		assert.NoError(t, ioutil.WriteFile(golden, buf.Bytes(), 0644))
+	}
+	expected, err := ioutil.ReadFile(golden)
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(expected), buf.String())
+	}
+}
+
+func TestParseSqlNullColumns(t *testing.T) {
+	sql := `CREATE TABLE t (
+  id int NOT NULL PRIMARY KEY,
+  t_description varchar(255) NOT NULL,
+  t_title varchar(64) NOT NULL,
+  t_note_a text NOT NULL,
+  t_count int NULL
+);`
+	data, err := ParseSql(sql, WithColumnPrefix("t_"), WithNullColumns("description", "note_*", "id"))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	code := data.StructCode[0]
+	assert.Regexp(t, "ID +int ", code)
+	assert.Regexp(t, "Description +sql.NullString `gorm:\"column:t_description;NOT NULL\"`", code)
+	assert.Regexp(t, "Title +string ", code)
+	assert.Regexp(t, "NoteA +sql.NullString ", code)
+	assert.Regexp(t, "Count +sql.NullInt32 ", code)
+
+	data, err = ParseSql(sql, WithColumnPrefix("t_"), WithNullColumns("description"), WithNoNullType())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Regexp(t, "Description +sql.NullString ", data.StructCode[0])
+		assert.Regexp(t, "Count +int ", data.StructCode[0])
+	}
+	data, err = ParseSql(sql, WithColumnPrefix("t_"), WithNullColumns("description"), WithNullStyle(NullInPointer))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Regexp(t, `Description +\*string `, data.StructCode[0])
+	}
+}
+
+func TestParseSqlAutoMigrateFunc(t *testing.T) {
+	sql := `CREATE TABLE users (id int PRIMARY KEY);
+CREATE TABLE shop_orders (id int PRIMARY KEY);`
+	data, err := ParseSql(sql)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Users", "ShopOrders"}, data.TypeNames)
+		assert.Equal(t, 2, len(data.StructCode))
+		assert.Empty(t, data.ImportPath)
+	}
+
+	data, err = ParseSql(sql, WithAutoMigrateFunc(""), WithSingularStruct())
+	if assert.NoError(t, err) && assert.Equal(t, 3, len(data.StructCode)) {
+		assert.Equal(t, []string{"User", "ShopOrder"}, data.TypeNames)
+		assert.Equal(t, []string{"gorm.io/gorm"}, data.ImportPath)
+		assert.Contains(t, data.StructCode[2], `func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(
+		&User{},
+		&ShopOrder{},
+	)
+}`)
+	}
+	buf := bytes.Buffer{}
+	if assert.NoError(t, ParseSqlToWrite(sql, &buf, WithAutoMigrateFunc(""))) {
+		assert.Contains(t, buf.String(), "\"gorm.io/gorm\"")
+		assert.Contains(t, buf.String(), "\t\t&ShopOrders{},\n")
+	}
+
+	// only for models of gorm
+	data, err = ParseSql(sql, WithAutoMigrateFunc(""), WithORM(ORMXorm))
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, len(data.StructCode))
+	}
+
+	dir := t.TempDir()
+	if assert.NoError(t, ParseSqlToFiles(sql, dir, WithAutoMigrateFunc(""))) {
+		b, err := ioutil.ReadFile(filepath.Join(dir, "auto_migrate.go"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(b), "package model\n")
+			assert.Contains(t, string(b), "&Users{},")
+		}
+		b, err = ioutil.ReadFile(filepath.Join(dir, "users.go"))
+		if assert.NoError(t, err) {
+			assert.NotContains(t, string(b), "AutoMigrate")
+		}
+	}
+}
+
+func TestParseSqlAutoMigrateFuncName(t *testing.T) {
+	sql := "CREATE TABLE users (id int PRIMARY KEY);"
+	data, err := ParseSql(sql, WithAutoMigrateFunc("MigrateModels"))
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[1], "// MigrateModels migrates the tables of generated models\nfunc MigrateModels(db *gorm.DB) error {")
+	}
+	_, err = ParseSql(sql, WithAutoMigrateFunc("auto-migrate"))
+	assert.EqualError(t, err, "invalid function name of auto migrate: auto-migrate")
+}
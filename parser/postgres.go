@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"database/sql"
+	"strings"
+
+	_ "github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+type pgTable struct {
+	Name        string
+	Columns     []pgColumn
+	Constraints []pgConstraint
+	Indexes     []string // CREATE INDEX statements
+}
+
+type pgColumn struct {
+	Name    string
+	Type    string
+	NotNull bool
+	Default sql.NullString
+	Comment sql.NullString
+}
+
+type pgConstraint struct {
+	Name string
+	Def  string
+}
+
+// IsPostgresDsn reports whether dsn is a postgres connection url
+func IsPostgresDsn(dsn string) bool {
+	return strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://")
+}
+
+// GetCreateTableFromPostgres makes a CREATE TABLE statement from the catalog
+// of postgres, indexes are appended as CREATE INDEX statements. table may be
+// qualified by schema, it's parsed with WithDialect(DialectPostgres).
+func GetCreateTableFromPostgres(dsn, tableName string) (string, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return "", errors.WithMessage(err, "open db error")
+	}
+	defer db.Close()
+	return getPostgresCreateTable(db, tableName)
+}
+
+// GetAllCreateTablesFromPostgres makes CREATE TABLE statements of every base
+// table in the current schema of dsn, views are excluded.
+func GetAllCreateTablesFromPostgres(dsn string) ([]string, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, errors.WithMessage(err, "open db error")
+	}
+	defer db.Close()
+	rows, err := db.Query(`SELECT quote_ident(table_name) FROM information_schema.tables
+WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name`)
+	if err != nil {
+		return nil, errors.WithMessage(err, "query tables error")
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	creates := make([]string, 0, len(names))
+	for _, name := range names {
+		createSql, err := getPostgresCreateTable(db, name)
+		if err != nil {
+			return nil, err
+		}
+		creates = append(creates, createSql)
+	}
+	return creates, nil
+}
+
+func getPostgresCreateTable(db *sql.DB, tableName string) (string, error) {
+	table := pgTable{}
+	err := db.QueryRow(
+		"SELECT c.relname FROM pg_class c WHERE c.oid = to_regclass($1)", tableName,
+	).Scan(&table.Name)
+	if err == sql.ErrNoRows {
+		return "", errors.Errorf("table(%s) not found", tableName)
+	}
+	if err != nil {
+		return "", errors.WithMessage(err, "query table error")
+	}
+
+	rows, err := db.Query(`SELECT a.attname, format_type(a.atttypid, a.atttypmod), a.attnotnull,
+       pg_get_expr(d.adbin, d.adrelid), col_description(a.attrelid, a.attnum)
+FROM pg_attribute a
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attrelid = to_regclass($1) AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`, tableName)
+	if err != nil {
+		return "", errors.WithMessage(err, "query columns error")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c := pgColumn{}
+		if err := rows.Scan(&c.Name, &c.Type, &c.NotNull, &c.Default, &c.Comment); err != nil {
+			return "", err
+		}
+		table.Columns = append(table.Columns, c)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	rows, err = db.Query(`SELECT conname, pg_get_constraintdef(oid) FROM pg_constraint
+WHERE conrelid = to_regclass($1) AND contype IN ('p', 'u', 'f') ORDER BY contype = 'p' DESC, conname`, tableName)
+	if err != nil {
+		return "", errors.WithMessage(err, "query constraints error")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c := pgConstraint{}
+		if err := rows.Scan(&c.Name, &c.Def); err != nil {
+			return "", err
+		}
+		table.Constraints = append(table.Constraints, c)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	// indexes of constraints are made by the constraints already
+	rows, err = db.Query(`SELECT pg_get_indexdef(x.indexrelid) FROM pg_index x
+WHERE x.indrelid = to_regclass($1)
+  AND NOT EXISTS (SELECT 1 FROM pg_constraint c WHERE c.conindid = x.indexrelid)
+ORDER BY x.indexrelid`, tableName)
+	if err != nil {
+		return "", errors.WithMessage(err, "query indexes error")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var def string
+		if err := rows.Scan(&def); err != nil {
+			return "", err
+		}
+		table.Indexes = append(table.Indexes, def)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	return table.createSql(), nil
+}
+
+func ParseSqlFromPostgres(dsn, tableName string, options ...Option) (ModelCodes, error) {
+	createSql, err := GetCreateTableFromPostgres(dsn, tableName)
+	if err != nil {
+		return ModelCodes{}, err
+	}
+	return ParseSql(createSql, append(options, WithDialect(DialectPostgres))...)
+}
+
+// createSql writes the table in postgres syntax, comments of columns are
+// written like MySQL since postgres keeps them in COMMENT ON statements.
+func (t pgTable) createSql() string {
+	b := strings.Builder{}
+	b.WriteString("CREATE TABLE ")
+	b.WriteString(pgQuoteIdent(t.Name))
+	b.WriteString(" (")
+	for i, c := range t.Columns {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString("\n  ")
+		b.WriteString(pgQuoteIdent(c.Name))
+		b.WriteByte(' ')
+		b.WriteString(c.Type)
+		if c.NotNull {
+			b.WriteString(" NOT NULL")
+		}
+		if c.Default.Valid {
+			b.WriteString(" DEFAULT ")
+			b.WriteString(c.Default.String)
+		}
+		if c.Comment.Valid {
+			b.WriteString(" COMMENT ")
+			b.WriteString(pgQuoteString(c.Comment.String))
+		}
+	}
+	for _, c := range t.Constraints {
+		b.WriteString(",\n  CONSTRAINT ")
+		b.WriteString(pgQuoteIdent(c.Name))
+		b.WriteByte(' ')
+		b.WriteString(c.Def)
+	}
+	b.WriteString("\n);\n")
+	for _, idx := range t.Indexes {
+		b.WriteString(idx)
+		b.WriteString(";\n")
+	}
+	return b.String()
+}
+
+func pgQuoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func pgQuoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+)
+
+const protoTimestamp = "google/protobuf/timestamp.proto"
+
+var (
+	protoMessageTmpl = template.Must(template.New("protoMessage").Parse(`
+{{- range .Comment -}}
+// {{.}}
+{{end -}}
+message {{.TableName}} {
+{{- range .Fields}}
+{{- range .Doc}}
+  // {{.}}
+{{- end}}
+  {{.GoType}} {{.Name}} = {{.Tag}};
+{{- end}}
+}
+`))
+	protoFileTmpl = template.Must(template.New("protoFile").Parse(fileHeaderTmpl + `
+syntax = "proto3";
+
+package {{.Package}};
+{{if .ImportPath}}
+{{range .ImportPath}}import "{{.}}";
+{{end}}
+{{- end}}
+{{- range .StructCode}}
+{{.}}{{end}}`))
+)
+
+// makeProto makes a message of proto3 for table, fields are numbered in the
+// order of columns and named in snake case. Nullable fields are optional.
+func makeProto(table TableInfo, opt options) (string, []string, error) {
+	data := tmplData{
+		TableName: structName(table.Name, opt),
+		Fields:    make([]tmplField, 0, len(table.Columns)),
+		Comment:   commentLines(table.Comment),
+	}
+	var importPath []string
+	for _, col := range table.Columns {
+		name := trimColumnPrefix(col.Name, opt)
+		if matchColumn(name, opt.ExcludeColumns) {
+			continue
+		}
+		tp := protoType(col, opt)
+		if tp == "google.protobuf.Timestamp" {
+			importPath = append(importPath, protoTimestamp)
+		}
+		if col.hint.ArrayDims > 0 || col.tp.Tp == mysql.TypeSet {
+			tp = "repeated " + tp
+		} else if col.Nullable {
+			tp = "optional " + tp
+		}
+		field := tmplField{
+			Name:   protoFieldName(name, len(data.Fields)+1),
+			GoType: tp,
+			Tag:    strconv.Itoa(len(data.Fields) + 1),
+			Doc:    commentLines(col.Comment),
+		}
+		data.Fields = append(data.Fields, field)
+	}
+	builder := strings.Builder{}
+	if err := protoMessageTmpl.Execute(&builder, data); err != nil {
+		return "", nil, err
+	}
+	return builder.String(), importPath, nil
+}
+
+// protoType maps a column to a scalar type of proto3, times are Timestamp
+func protoType(col ColumnInfo, opt options) string {
+	unsigned := mysql.HasUnsignedFlag(col.tp.Flag)
+	if isTinyIntBool(col.tp, opt) || col.hint.GoType == "bool" {
+		return "bool"
+	}
+	if col.hint.GoType == "[]byte" {
+		return "bytes"
+	}
+	switch col.tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		if unsigned {
+			return "uint32"
+		}
+		return "int32"
+	case mysql.TypeLonglong:
+		if unsigned {
+			return "uint64"
+		}
+		return "int64"
+	case mysql.TypeFloat:
+		return "float"
+	case mysql.TypeDouble:
+		return "double"
+	case mysql.TypeBit:
+		if col.tp.Flen <= 1 {
+			return "bool"
+		}
+		return "uint64"
+	case mysql.TypeTimestamp, mysql.TypeDatetime:
+		return "google.protobuf.Timestamp"
+	}
+	if col.tp.Charset == "binary" && (isCharType(col.tp.Tp) || isTextType(col.tp.Tp)) {
+		return "bytes"
+	}
+	// char, text, decimal, enum, json, date and others
+	return "string"
+}
+
+// protoFieldName converts a column name to snake case, n is the number of
+// field used if no letter is left.
+func protoFieldName(column string, n int) string {
+	name := toSnake(toCamel(column))
+	if name == "" {
+		return "column_" + strconv.Itoa(n)
+	}
+	if isDigit(name[0]) {
+		return "column_" + name
+	}
+	return name
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlProto(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint unsigned NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  email varchar(255) NOT NULL COMMENT 'login email',
+  nickname varchar(32) NULL,
+  created_at datetime NOT NULL
+) COMMENT 'all users';`
+	buf := bytes.Buffer{}
+	err := ParseSqlToWrite(sql, &buf, WithTarget(TargetProto), WithPackage("shop.v1"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+
+syntax = "proto3";
+
+package shop.v1;
+
+import "google/protobuf/timestamp.proto";
+
+// all users
+message Users {
+  uint64 id = 1;
+  // login email
+  string email = 2;
+  optional string nickname = 3;
+  google.protobuf.Timestamp created_at = 4;
+}
+`, buf.String())
+
+	// columns are nullable without NULL, whatever the null style of Go
+	data, err := ParseSql(`CREATE TABLE t (a int, b int NOT NULL);`, WithTarget(TargetProto), WithNoNullType())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "  optional int32 a = 1;\n  int32 b = 2;\n")
+	}
+}
+
+func TestParseSqlProtoTypes(t *testing.T) {
+	sql := `CREATE TABLE types (
+  a tinyint NOT NULL,
+  b smallint unsigned NOT NULL,
+  c mediumint NOT NULL,
+  d int unsigned NOT NULL,
+  e bigint NOT NULL,
+  f float NOT NULL,
+  g double NOT NULL,
+  h decimal(10,2) NOT NULL,
+  i tinyint(1) NOT NULL,
+  j bit(1) NOT NULL,
+  k bit(8) NOT NULL,
+  l char(10) NOT NULL,
+  m text NOT NULL,
+  n blob NOT NULL,
+  o binary(16) NOT NULL,
+  p varbinary(255) NOT NULL,
+  q date NOT NULL,
+  r timestamp NOT NULL,
+  s time NOT NULL,
+  t year NOT NULL,
+  u enum('x','y') NOT NULL,
+  v set('x','y') NOT NULL,
+  w json NOT NULL
+);`
+	data, err := ParseSql(sql, WithTarget(TargetProto), WithTinyIntBool())
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	for _, field := range []string{
+		"int32 a = 1;", "uint32 b = 2;", "int32 c = 3;", "uint32 d = 4;", "int64 e = 5;",
+		"float f = 6;", "double g = 7;", "string h = 8;", "bool i = 9;", "bool j = 10;",
+		"uint64 k = 11;", "string l = 12;", "string m = 13;", "bytes n = 14;", "bytes o = 15;",
+		"bytes p = 16;", "string q = 17;", "google.protobuf.Timestamp r = 18;", "string s = 19;",
+		"int32 t = 20;", "string u = 21;", "repeated string v = 22;", "string w = 23;",
+	} {
+		assert.Contains(t, data.StructCode[0], "  "+field+"\n")
+	}
+	assert.Equal(t, []string{"google/protobuf/timestamp.proto"}, data.ImportPath)
+
+	data, err = ParseSql(`CREATE TABLE posts (id serial PRIMARY KEY, tags text[] NULL, body bytea NOT NULL);`,
+		WithTarget(TargetProto), WithDialect(DialectPostgres))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "  repeated string tags = 2;\n")
+		assert.Contains(t, data.StructCode[0], "  bytes body = 3;\n")
+	}
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"strings"
+	"text/template"
+)
+
+var repositoryTmpl = template.Must(template.New("repository").Parse(`
+// {{.Interface}} reads and writes {{.Model}}
+type {{.Interface}} interface {
+	Create(ctx context.Context, m *{{.Model}}) error
+	FindByID(ctx context.Context{{range .Keys}}, {{.Param}} {{.Type}}{{end}}) (*{{.Model}}, error)
+	Update(ctx context.Context, m *{{.Model}}) error
+	Delete(ctx context.Context{{range .Keys}}, {{.Param}} {{.Type}}{{end}}) error
+}
+
+type {{.Impl}} struct {
+	db *gorm.DB
+}
+
+// New{{.Interface}} makes a {{.Interface}} by gorm
+func New{{.Interface}}(db *gorm.DB) {{.Interface}} {
+	return &{{.Impl}}{db: db}
+}
+
+func (r *{{.Impl}}) Create(ctx context.Context, m *{{.Model}}) error {
+	return r.db.WithContext(ctx).Create(m).Error
+}
+
+func (r *{{.Impl}}) FindByID(ctx context.Context{{range .Keys}}, {{.Param}} {{.Type}}{{end}}) (*{{.Model}}, error) {
+	var m {{.Model}}
+	if err := r.db.WithContext(ctx).Where({{printf "%q" .Where}}{{range .Keys}}, {{.Param}}{{end}}).First(&m).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *{{.Impl}}) Update(ctx context.Context, m *{{.Model}}) error {
+	return r.db.WithContext(ctx).Save(m).Error
+}
+
+func (r *{{.Impl}}) Delete(ctx context.Context{{range .Keys}}, {{.Param}} {{.Type}}{{end}}) error {
+	return r.db.WithContext(ctx).Where({{printf "%q" .Where}}{{range .Keys}}, {{.Param}}{{end}}).Delete(&{{.Model}}{}).Error
+}
+`))
+
+type tmplRepository struct {
+	Interface string
+	Impl      string
+	Model     string
+	Where     string // condition of primary keys, e.g. "id = ?"
+	Keys      []tmplRepositoryKey
+}
+
+type tmplRepositoryKey struct {
+	Column string
+	Param  string
+	Type   string
+}
+
+func newRepositoryKey(column, field, goType string) tmplRepositoryKey {
+	param := unexportedName(field)
+	switch param {
+	case "ctx", "m", "r", "err":
+		// names used by the methods
+		param += "Key"
+	}
+	return tmplRepositoryKey{Column: column, Param: param, Type: goType}
+}
+
+// makeRepository makes the repository of model by its primary keys, it's nil
+// if there is no primary key.
+func makeRepository(model string, keys []tmplRepositoryKey) *tmplRepository {
+	if len(keys) == 0 {
+		return nil
+	}
+	conditions := make([]string, 0, len(keys))
+	for _, k := range keys {
+		conditions = append(conditions, k.Column+" = ?")
+	}
+	return &tmplRepository{
+		Interface: model + "Repository",
+		Impl:      unexportedName(model + "Repository"),
+		Model:     model,
+		Where:     strings.Join(conditions, " AND "),
+		Keys:      keys,
+	}
+}
@@ -0,0 +1,77 @@
+package parser
+
+import (
+	gotoken "go/token"
+	gotypes "go/types"
+	"strings"
+	"text/template"
+)
+
+var jsonScannerTmpl = template.Must(template.New("jsonScanner").Parse(`
+// Value marshals {{.}} to JSON for database/sql
+func (v {{.}}) Value() (driver.Value, error) {
+	return json.Marshal(v)
+}
+
+// Scan unmarshals JSON of a column into {{.}}
+func (v *{{.}}) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case nil:
+		var zero {{.}}
+		*v = zero
+		return nil
+	case []byte:
+		return json.Unmarshal(src, v)
+	case string:
+		return json.Unmarshal([]byte(src), v)
+	}
+	return fmt.Errorf("can't scan %T into {{.}}", src)
+}
+`))
+
+// stringSetType is the type of SET columns, database/sql can't scan the values
+// joined by comma into []string
+const stringSetType = "StringSet"
+
+var stringSetTmpl = template.Must(template.New("stringSet").Parse(`
+// {{.}} is the values of a SET column, NULL is scanned as nil
+type {{.}} []string
+
+// Value joins the values by comma for database/sql
+func (s {{.}}) Value() (driver.Value, error) {
+	return strings.Join(s, ","), nil
+}
+
+// Scan splits the values of a SET column by comma
+func (s *{{.}}) Scan(src interface{}) error {
+	var v string
+	switch src := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		v = string(src)
+	case string:
+		v = src
+	default:
+		return fmt.Errorf("can't scan %T into {{.}}", src)
+	}
+	if v == "" {
+		*s = {{.}}{}
+		return nil
+	}
+	*s = strings.Split(v, ",")
+	return nil
+}
+`))
+
+// jsonScannerType gets the named type of a JSON column which needs Scan and
+// Value, pkg is the import path of the type. A type of slice or map can't have
+// methods and isn't returned.
+func jsonScannerType(goType, pkg string) (string, bool) {
+	name := strings.TrimPrefix(goType, "*")
+	if pkg != "" || !gotoken.IsIdentifier(name) || gotypes.Universe.Lookup(name) != nil {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"strings"
+	"text/template"
+)
+
+var stringerTmpl = template.Must(template.New("stringer").Parse(`
+// String returns the fields of {{.Model}} for logging
+func (m {{.Model}}) String() string {
+	b := strings.Builder{}
+	b.WriteString("{{.Model}}{")
+{{- range .Fields}}
+{{- if .Redacted}}
+	b.WriteString("{{.Label}}<redacted>")
+{{- else if .Value}}
+	if m.{{.Name}}.Valid {
+		fmt.Fprintf(&b, "{{.Label}}{{.Verb}}", m.{{.Name}}.{{.Value}})
+	} else {
+		b.WriteString("{{.Label}}NULL")
+	}
+{{- else if .Pointer}}
+	if m.{{.Name}} != nil {
+		fmt.Fprintf(&b, "{{.Label}}{{.Verb}}", *m.{{.Name}})
+	} else {
+		b.WriteString("{{.Label}}NULL")
+	}
+{{- else}}
+	fmt.Fprintf(&b, "{{.Label}}{{.Verb}}", m.{{.Name}})
+{{- end}}
+{{- end}}
+	b.WriteString("}")
+	return b.String()
+}
+`))
+
+type tmplStringer struct {
+	Model  string
+	Fields []tmplStringField
+}
+
+// tmplStringField is a field written by String()
+type tmplStringField struct {
+	Name     string
+	Label    string // written before the value, e.g. ", Email: "
+	Verb     string // verb of fmt for the value
+	Value    string // value field of sql.NullXXX, empty for others
+	Pointer  bool
+	Redacted bool
+}
+
+func newStringField(name, goType string, redacted bool) tmplStringField {
+	f := tmplStringField{Name: name, Redacted: redacted}
+	if v, ok := sqlNullValues[goType]; ok {
+		f.Value, goType = v.Field, v.Type
+	} else if goType == "gorm.DeletedAt" {
+		f.Value, goType = "Time", "time.Time"
+	} else if strings.HasPrefix(goType, "*") {
+		f.Pointer, goType = true, goType[1:]
+	}
+	switch goType {
+	case "string", "[]byte", "[]string":
+		f.Verb = "%q"
+	case "json.RawMessage", "datatypes.JSON":
+		f.Verb = "%s"
+	default:
+		f.Verb = "%v"
+	}
+	return f
+}
+
+// makeStringer makes String() of model, it's nil if a field is named String.
+// The import paths used by the method are returned.
+func makeStringer(model string, fields []tmplStringField) (*tmplStringer, []string) {
+	importPath := []string{"strings"}
+	for i := range fields {
+		f := &fields[i]
+		if f.Name == "String" {
+			return nil, nil
+		}
+		f.Label = f.Name + ": "
+		if i > 0 {
+			f.Label = ", " + f.Label
+		}
+		if !f.Redacted && len(importPath) == 1 {
+			importPath = append(importPath, "fmt")
+		}
+	}
+	return &tmplStringer{Model: model, Fields: fields}, importPath
+}
@@ -0,0 +1,426 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/ast"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/types"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/parser"
+)
+
+// TableInfo is a table parsed from CREATE TABLE statement
+type TableInfo struct {
+	Name        string
+	Schema      string // schema or database of a qualified name, e.g. shop of shop.users
+	Comment     string
+	View        bool   // it's made by CREATE VIEW, columns are read only
+	Charset     string // default character set of table, empty if not declared
+	Collation   string
+	Columns     []ColumnInfo
+	Indexes     []IndexInfo
+	ForeignKeys []ForeignKeyInfo
+	Checks      []CheckInfo
+}
+
+// ColumnInfo is a column of table
+type ColumnInfo struct {
+	Name            string
+	Type            string // declared type, e.g. "varchar(255)", "int(10) unsigned"
+	Length          int    // length or precision of type, -1 if not declared
+	Scale           int    // digits after the decimal point, -1 if not declared
+	Unsigned        bool
+	Elems           []string // values of enum and set
+	NotNull         bool
+	Nullable        bool // NULL is allowed, it's not declared NOT NULL or primary key
+	PrimaryKey      bool
+	AutoIncrement   bool
+	Unique          bool
+	HasDefault      bool
+	Default         string // default value, a function name like CURRENT_TIMESTAMP for function
+	DefaultIsString bool
+	OnUpdate        string // function of ON UPDATE, e.g. CURRENT_TIMESTAMP(3)
+	Comment         string
+	Charset         string // character set declared by the column, empty for the default of table
+	Collation       string
+	Generated       string // expression of a generated column
+	Stored          bool   // the generated column is STORED rather than VIRTUAL
+
+	tp           *types.FieldType
+	hint         columnHint
+	nullDeclared bool
+	ann          columnAnnotation
+	refKey       *types.FieldType // type of the auto increment key the column references
+}
+
+// IndexInfo is an index or key of table
+type IndexInfo struct {
+	Name     string
+	Columns  []string
+	Primary  bool
+	Unique   bool
+	Fulltext bool
+	Spatial  bool // an index of a spatial column, e.g. SPATIAL INDEX
+}
+
+// ForeignKeyInfo is a foreign key declared by REFERENCES or FOREIGN KEY
+type ForeignKeyInfo struct {
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// ParseTables parses CREATE TABLE, CREATE INDEX and ALTER TABLE statements in
+// sql, and CREATE VIEW with WithIncludeViews. Options about the SQL like
+// WithDialect and WithCharset are used, others are ignored.
+func ParseTables(sql string, options ...Option) ([]TableInfo, error) {
+	tables, ctx, err := parseTableInfos(sql, parseOption(options))
+	if ctx != nil {
+		logWarnings(ctx.warnings)
+	}
+	return tables, err
+}
+
+func parseTableInfos(sql string, opt options) ([]TableInfo, *parseContext, error) {
+	sql, hints, warnings, err := translateDialect(sql, opt.Dialect)
+	if err != nil {
+		return nil, nil, err
+	}
+	stmts, err := parser.New().Parse(sql, opt.Charset, opt.Collation)
+	if err != nil {
+		return nil, nil, toParseError(err)
+	}
+	ctx := &parseContext{
+		tables:   make(map[string]struct{}),
+		enums:    make(map[string]string),
+		embedded: make(map[string]string),
+		scanners: make(map[string]struct{}),
+		warnings: warnings,
+	}
+	tables := make([]TableInfo, 0, len(stmts))
+	views := 0
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.CreateTableStmt:
+			ctx.tables[stmt.Table.Name.L] = struct{}{}
+			tables = append(tables, newTableInfo(stmt, hints[stmt.Table.Name.L]))
+		case *ast.CreateIndexStmt:
+			addIndex(tables, stmt)
+		case *ast.AlterTableStmt:
+			alterTable(tables, stmt, hints[stmt.Table.Name.L], ctx)
+		case *ast.CreateViewStmt:
+			if !opt.IncludeViews {
+				views++
+				continue
+			}
+			ctx.tables[stmt.ViewName.Name.L] = struct{}{}
+			tables = append(tables, newViewInfo(stmt, tables, ctx))
+		}
+	}
+	if views == 1 {
+		ctx.warn("", "", "1 view is skipped")
+	} else if views > 1 {
+		ctx.warn("", "", "%d views are skipped", views)
+	}
+	for i := range tables {
+		markSpatialIndexes(&tables[i])
+	}
+	markKeyReferences(tables)
+	if opt.Annotations {
+		// after ALTER TABLE which may add columns
+		for i := range tables {
+			applyAnnotations(&tables[i])
+		}
+	}
+	return tables, ctx, nil
+}
+
+// addIndex adds an index created by CREATE INDEX to its table
+func addIndex(tables []TableInfo, stmt *ast.CreateIndexStmt) {
+	for i := len(tables) - 1; i >= 0; i-- {
+		if !strings.EqualFold(tables[i].Name, stmt.Table.Name.O) {
+			continue
+		}
+		idx := IndexInfo{Name: stmt.IndexName, Unique: stmt.Unique}
+		for _, c := range stmt.IndexColNames {
+			idx.Columns = append(idx.Columns, c.Column.Name.String())
+		}
+		tables[i].Indexes = append(tables[i].Indexes, idx)
+		return
+	}
+}
+
+func newTableInfo(stmt *ast.CreateTableStmt, hints tableHints) TableInfo {
+	table := TableInfo{
+		Name:    stmt.Table.Name.String(),
+		Schema:  stmt.Table.Schema.String(),
+		Columns: make([]ColumnInfo, 0, len(stmt.Cols)),
+	}
+	for _, opt := range stmt.Options {
+		switch opt.Tp {
+		case ast.TableOptionComment:
+			table.Comment = opt.StrValue
+		case ast.TableOptionCharset:
+			table.Charset = opt.StrValue
+		case ast.TableOptionCollate:
+			table.Collation = opt.StrValue
+		}
+	}
+
+	isPrimaryKey := make(map[string]bool)
+	for _, con := range stmt.Constraints {
+		idx, ok := newIndexInfo(con)
+		if !ok {
+			continue
+		}
+		if idx.Primary {
+			for _, key := range con.Keys {
+				isPrimaryKey[key.Column.Name.L] = true
+			}
+		}
+		table.Indexes = append(table.Indexes, idx)
+	}
+
+	for _, col := range stmt.Cols {
+		c, keys := newColumnInfo(col, isPrimaryKey[col.Name.Name.L], hints)
+		table.Columns = append(table.Columns, c)
+		table.ForeignKeys = append(table.ForeignKeys, keys...)
+	}
+	addInlinePrimaryKey(&table)
+	markAutoIncrementKey(&table)
+	table.Checks = resolveChecks(hints.checks, table.Columns)
+
+	for _, con := range stmt.Constraints {
+		if con.Tp == ast.ConstraintForeignKey && con.Refer != nil {
+			cols := make([]string, 0, len(con.Keys))
+			for _, k := range con.Keys {
+				cols = append(cols, k.Column.Name.String())
+			}
+			table.ForeignKeys = append(table.ForeignKeys, newForeignKey(cols, con.Refer))
+		}
+	}
+	return table
+}
+
+// newIndexInfo gets the index of a primary key, key or index constraint, it's
+// false for other constraints.
+func newIndexInfo(con *ast.Constraint) (IndexInfo, bool) {
+	idx := IndexInfo{Name: con.Name}
+	switch con.Tp {
+	case ast.ConstraintPrimaryKey:
+		idx.Primary = true
+	case ast.ConstraintKey, ast.ConstraintIndex:
+	case ast.ConstraintFulltext:
+		idx.Fulltext = true
+	case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		idx.Unique = true
+	default:
+		return idx, false
+	}
+	for _, key := range con.Keys {
+		idx.Columns = append(idx.Columns, key.Column.Name.String())
+	}
+	return idx, true
+}
+
+// newColumnInfo gets a column and its foreign keys declared by REFERENCES,
+// primaryKey is true if the column is in PRIMARY KEY of table.
+func newColumnInfo(col *ast.ColumnDef, primaryKey bool, hints tableHints) (ColumnInfo, []ForeignKeyInfo) {
+	c := ColumnInfo{
+		Name:       col.Name.Name.String(),
+		Type:       col.Tp.InfoSchemaStr(),
+		Length:     col.Tp.Flen,
+		Scale:      col.Tp.Decimal,
+		Unsigned:   mysql.HasUnsignedFlag(col.Tp.Flag),
+		Elems:      col.Tp.Elems,
+		PrimaryKey: primaryKey,
+		tp:         col.Tp,
+		hint:       hints.columns[col.Name.Name.L],
+	}
+	if col.Tp.Charset != "binary" {
+		c.Charset, c.Collation = col.Tp.Charset, col.Tp.Collate
+	}
+	if c.hint.RawType != "" {
+		c.Type = c.hint.RawType
+	}
+	if c.hint.Default != "" {
+		c.HasDefault, c.Default = true, c.hint.Default
+	}
+	var keys []ForeignKeyInfo
+	for _, o := range col.Options {
+		switch o.Tp {
+		case ast.ColumnOptionPrimaryKey:
+			c.PrimaryKey = true
+		case ast.ColumnOptionNotNull:
+			c.NotNull = true
+		case ast.ColumnOptionAutoIncrement:
+			c.AutoIncrement = true
+		case ast.ColumnOptionDefaultValue:
+			c.HasDefault = true
+			c.Default = timeWithFsp(getDefaultValue(o.Expr), c.hint.DefaultFsp)
+			c.DefaultIsString = o.Expr.GetDatum().Kind() == types.KindString
+		case ast.ColumnOptionOnUpdate:
+			c.OnUpdate = timeWithFsp(getDefaultValue(o.Expr), c.hint.OnUpdateFsp)
+		case ast.ColumnOptionUniqKey:
+			c.Unique = true
+		case ast.ColumnOptionNull:
+			c.nullDeclared = true
+		case ast.ColumnOptionComment:
+			c.Comment = o.Expr.GetDatum().GetString()
+		case ast.ColumnOptionGenerated:
+			c.Generated = strings.Join(strings.Fields(o.Expr.Text()), " ")
+			c.Stored = o.Stored
+		case ast.ColumnOptionReference:
+			if o.Refer != nil {
+				keys = append(keys, newForeignKey([]string{c.Name}, o.Refer))
+			}
+		}
+	}
+	c.Nullable = !c.NotNull && !c.PrimaryKey
+	// NOT NULL and primary key win over NULL, e.g. "id int NULL PRIMARY KEY"
+	c.nullDeclared = c.nullDeclared && c.Nullable
+	return c, keys
+}
+
+// spatialTypes are types of MySQL and PostGIS for geometries
+var spatialTypes = map[string]struct{}{
+	"geometry": {}, "point": {}, "linestring": {}, "polygon": {}, "multipoint": {}, "multilinestring": {},
+	"multipolygon": {}, "geometrycollection": {}, "geomcollection": {}, "geography": {},
+}
+
+// isSpatialColumn reports whether the column is a geometry, e.g. point or
+// geometry(Point,4326) of PostGIS. A geometric type of postgres like point
+// isn't known and isn't spatial.
+func isSpatialColumn(col ColumnInfo) bool {
+	if col.hint.Unknown || col.hint.ArrayDims > 0 {
+		return false
+	}
+	name := col.hint.RawType
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+	_, ok := spatialTypes[name]
+	return ok
+}
+
+// markSpatialIndexes marks the index of a spatial column SPATIAL, the parser
+// drops SPATIAL of the index and MySQL can't make other indexes of it.
+func markSpatialIndexes(table *TableInfo) {
+	for i := range table.Indexes {
+		idx := &table.Indexes[i]
+		if idx.Primary || idx.Unique || idx.Fulltext || len(idx.Columns) != 1 {
+			continue
+		}
+		if c := findColumn(table, idx.Columns[0]); c >= 0 && isSpatialColumn(table.Columns[c]) {
+			idx.Spatial = true
+		}
+	}
+}
+
+// addInlinePrimaryKey adds the index of PRIMARY KEY declared in a column
+// definition, e.g. "id int PRIMARY KEY", the same as PRIMARY KEY (id) of table.
+func addInlinePrimaryKey(table *TableInfo) {
+	for _, idx := range table.Indexes {
+		if idx.Primary {
+			return
+		}
+	}
+	var cols []string
+	for _, c := range table.Columns {
+		if c.PrimaryKey {
+			cols = append(cols, c.Name)
+		}
+	}
+	if len(cols) > 0 {
+		table.Indexes = append([]IndexInfo{{Columns: cols, Primary: true}}, table.Indexes...)
+	}
+}
+
+// markAutoIncrementKey makes the AUTO_INCREMENT column primary key if the
+// table doesn't declare one, MySQL requires it to be a key. A declared primary
+// key wins, gorm would take both columns as a composite key otherwise.
+func markAutoIncrementKey(table *TableInfo) {
+	for _, c := range table.Columns {
+		if c.PrimaryKey {
+			return
+		}
+	}
+	for i := range table.Columns {
+		c := &table.Columns[i]
+		if c.AutoIncrement {
+			c.PrimaryKey = true
+			c.Nullable = false
+			c.nullDeclared = false
+			return
+		}
+	}
+}
+
+// markKeyReferences gives a foreign key column the type of the auto increment
+// primary key it references, the key is made unsigned and the reference must
+// hold its values.
+func markKeyReferences(tables []TableInfo) {
+	for i := range tables {
+		for _, key := range tables[i].ForeignKeys {
+			if len(key.Columns) != 1 || len(key.RefColumns) > 1 {
+				continue
+			}
+			ref := refKeyColumn(tables, key)
+			if ref == nil || !ref.PrimaryKey || !ref.AutoIncrement {
+				continue
+			}
+			if c := findColumn(&tables[i], key.Columns[0]); c >= 0 {
+				tables[i].Columns[c].refKey = autoIncrementType(*ref)
+			}
+		}
+	}
+}
+
+// refKeyColumn is the column referenced by a single column foreign key, the
+// primary key of the referenced table if the key doesn't name the column.
+func refKeyColumn(tables []TableInfo, key ForeignKeyInfo) *ColumnInfo {
+	for i := range tables {
+		t := &tables[i]
+		if !strings.EqualFold(t.Name, key.RefTable) {
+			continue
+		}
+		if len(key.RefColumns) == 1 {
+			if c := findColumn(t, key.RefColumns[0]); c >= 0 {
+				return &t.Columns[c]
+			}
+			return nil
+		}
+		var pk *ColumnInfo
+		for j := range t.Columns {
+			if t.Columns[j].PrimaryKey {
+				if pk != nil {
+					return nil
+				}
+				pk = &t.Columns[j]
+			}
+		}
+		return pk
+	}
+	return nil
+}
+
+// timeWithFsp adds fsp the parser drops to a function of the current time,
+// e.g. CURRENT_TIMESTAMP(3)
+func timeWithFsp(value, fsp string) string {
+	if fsp == "" || !containsFold(mysqlTimeDefaults, value) {
+		return value
+	}
+	return value + "(" + fsp + ")"
+}
+
+func newForeignKey(cols []string, refer *ast.ReferenceDef) ForeignKeyInfo {
+	key := ForeignKeyInfo{
+		Columns:    cols,
+		RefTable:   refer.Table.Name.String(),
+		RefColumns: make([]string, 0, len(refer.IndexColNames)),
+	}
+	for _, c := range refer.IndexColNames {
+		key.RefColumns = append(key.RefColumns, c.Column.Name.String())
+	}
+	return key
+}
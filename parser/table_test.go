@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTables(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int(10) unsigned NOT NULL AUTO_INCREMENT,
+  email varchar(255) NOT NULL COMMENT 'login name',
+  balance decimal(10,2) DEFAULT '0.00',
+  status enum('active','banned') NULL,
+  shop_id int NOT NULL REFERENCES shops (id),
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (id),
+  UNIQUE KEY uk_email (email),
+  KEY idx_shop_status (shop_id, status)
+) COMMENT 'all users';
+CREATE TABLE shops (id int PRIMARY KEY);`
+	tables, err := ParseTables(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(tables)) {
+		return
+	}
+	users := tables[0]
+	assert.Equal(t, "users", users.Name)
+	assert.Equal(t, "all users", users.Comment)
+	if assert.Equal(t, 6, len(users.Columns)) {
+		id := users.Columns[0]
+		assert.Equal(t, "id", id.Name)
+		assert.Equal(t, "int(10) unsigned", id.Type)
+		assert.True(t, id.Unsigned)
+		assert.True(t, id.PrimaryKey)
+		assert.True(t, id.AutoIncrement)
+		assert.False(t, id.Nullable)
+
+		email := users.Columns[1]
+		assert.Equal(t, 255, email.Length)
+		assert.True(t, email.NotNull)
+		assert.Equal(t, "login name", email.Comment)
+
+		balance := users.Columns[2]
+		assert.Equal(t, 10, balance.Length)
+		assert.Equal(t, 2, balance.Scale)
+		assert.True(t, balance.Nullable)
+		assert.True(t, balance.HasDefault)
+		assert.Equal(t, "0.00", balance.Default)
+		assert.True(t, balance.DefaultIsString)
+
+		assert.Equal(t, []string{"active", "banned"}, users.Columns[3].Elems)
+		assert.Equal(t, "CURRENT_TIMESTAMP", users.Columns[5].Default)
+	}
+	assert.Equal(t, []IndexInfo{
+		{Columns: []string{"id"}, Primary: true},
+		{Name: "uk_email", Columns: []string{"email"}, Unique: true},
+		{Name: "idx_shop_status", Columns: []string{"shop_id", "status"}},
+	}, users.Indexes)
+	assert.Equal(t, []ForeignKeyInfo{
+		{Columns: []string{"shop_id"}, RefTable: "shops", RefColumns: []string{"id"}},
+	}, users.ForeignKeys)
+	assert.True(t, tables[1].Columns[0].PrimaryKey)
+}
+
+func TestParseTablesAlterTable(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id int NOT NULL,
+  name varchar(32) NOT NULL,
+  legacy_code varchar(8) NULL,
+  KEY idx_name_code (name, legacy_code)
+);
+CREATE TABLE shops (id int PRIMARY KEY);
+ALTER TABLE users ADD PRIMARY KEY (id);
+ALTER TABLE users ADD COLUMN phone varchar(20) NULL COMMENT 'mobile' AFTER id;
+ALTER TABLE users ADD COLUMN (shop_id int NOT NULL, region char(2) NULL);
+ALTER TABLE users ADD INDEX idx_shop (shop_id), ADD UNIQUE KEY uk_phone (phone);
+ALTER TABLE users ADD CONSTRAINT fk_shop FOREIGN KEY (shop_id) REFERENCES shops (id) ON DELETE CASCADE;
+ALTER TABLE users DROP COLUMN legacy_code;
+ALTER TABLE users MODIFY name varchar(64) NOT NULL;
+ALTER TABLE users CHANGE region area char(2) NULL FIRST;
+ALTER TABLE users RENAME COLUMN area TO zone;
+ALTER TABLE users ENGINE=InnoDB;
+ALTER TABLE missing ADD COLUMN x int;`
+	tables, err := ParseTables(sql)
+	if !assert.NoError(t, err) || !assert.Equal(t, 2, len(tables)) {
+		return
+	}
+	users := tables[0]
+	names := make([]string, 0, len(users.Columns))
+	for _, c := range users.Columns {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"area", "id", "phone", "name", "shop_id"}, names)
+	if len(users.Columns) == 5 {
+		assert.True(t, users.Columns[1].PrimaryKey)
+		assert.False(t, users.Columns[1].Nullable)
+		assert.Equal(t, "mobile", users.Columns[2].Comment)
+		assert.Equal(t, 64, users.Columns[3].Length)
+		assert.True(t, users.Columns[4].NotNull)
+	}
+	assert.Equal(t, []IndexInfo{
+		{Name: "idx_name_code", Columns: []string{"name"}},
+		{Columns: []string{"id"}, Primary: true},
+		{Name: "idx_shop", Columns: []string{"shop_id"}},
+		{Name: "uk_phone", Columns: []string{"phone"}, Unique: true},
+	}, users.Indexes)
+	assert.Equal(t, []ForeignKeyInfo{
+		{Columns: []string{"shop_id"}, RefTable: "shops", RefColumns: []string{"id"}},
+	}, users.ForeignKeys)
+
+	tables, err = ParseTables(`CREATE TABLE t (a int, b int, KEY idx_b (b));
+ALTER TABLE t DROP INDEX idx_b, DROP COLUMN a;`)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(tables)) {
+		assert.Equal(t, 1, len(tables[0].Columns))
+		assert.Equal(t, 0, len(tables[0].Indexes))
+	}
+}
+
+func TestParseTablesInlinePrimaryKey(t *testing.T) {
+	inline := `CREATE TABLE users (
+  id bigint NOT NULL PRIMARY KEY AUTO_INCREMENT,
+  name varchar(32) NOT NULL,
+  KEY idx_name (name)
+);`
+	clause := `CREATE TABLE users (
+  id bigint NOT NULL AUTO_INCREMENT,
+  name varchar(32) NOT NULL,
+  PRIMARY KEY (id),
+  KEY idx_name (name)
+);`
+	for _, d := range []Dialect{DialectMySQL, DialectPostgres, DialectSQLite} {
+		inlineTables, err := ParseTables(inline, WithDialect(d))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		clauseTables, err := ParseTables(clause, WithDialect(d))
+		if !assert.NoError(t, err) {
+			continue
+		}
+		assert.ElementsMatch(t, clauseTables[0].Indexes, inlineTables[0].Indexes)
+		assert.Equal(t, clauseTables[0].Columns[0].PrimaryKey, inlineTables[0].Columns[0].PrimaryKey)
+
+		opts := []Option{WithDialect(d), WithGormType(), WithIndexTags(), WithRepository()}
+		inlineData, err := ParseSql(inline, opts...)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		clauseData, err := ParseSql(clause, opts...)
+		if assert.NoError(t, err) {
+			assert.Equal(t, clauseData.StructCode, inlineData.StructCode)
+			assert.Contains(t, inlineData.StructCode[0], "primaryKey")
+		}
+	}
+}
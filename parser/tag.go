@@ -0,0 +1,361 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+)
+
+// columnMeta is what the ORM tag of a column is made of
+type columnMeta struct {
+	Name            string
+	Field           string // name of field in Go
+	Type            string // type in DDL
+	Size            int    // length of char and varchar
+	TextType        string // text or blob type of MySQL, e.g. longtext
+	Charset         string // character set of string column
+	Collation       string
+	PrimaryKey      bool
+	AutoIncrement   bool
+	ReadOnly        bool // the value is generated by database
+	NotNull         bool
+	CanNull         bool // NULL is declared
+	HasDefault      bool
+	Default         string
+	DefaultIsString bool
+	Array           bool // an array of postgres
+	StringType      bool // values of the type are strings, e.g. varchar, text and enum
+	Unique          bool
+	Comment         string
+	Indexes         []columnIndex
+	Checks          []string // settings of gorm check tag, e.g. "age_check,age >= 0"
+	SoftDelete      bool
+	AutoTime        string // "create" or "update" if gorm manages the time
+	UnixTime        string // "sec" or "milli" for integer AutoTime column
+}
+
+type columnIndex struct {
+	Name     string
+	Unique   bool
+	Fulltext bool
+	Spatial  bool
+	Priority int // order in a composite index, start from 1, 0 if single column
+}
+
+// getIndexes returns indexes of every column from KEY, INDEX and UNIQUE KEY
+func getIndexes(table TableInfo, opt options) map[string][]columnIndex {
+	indexes := make(map[string][]columnIndex)
+	used := make(map[string]struct{}, len(table.Indexes))
+	for _, index := range table.Indexes {
+		used[strings.ToLower(index.Name)] = struct{}{}
+	}
+	for _, index := range table.Indexes {
+		if index.Primary {
+			continue
+		}
+		name := index.Name
+		if name == "" {
+			name = indexName(table.Name, index, used, opt)
+		}
+		idx := columnIndex{Name: name, Unique: index.Unique, Fulltext: index.Fulltext, Spatial: index.Spatial}
+		for i, col := range index.Columns {
+			if len(index.Columns) > 1 {
+				idx.Priority = i + 1
+			}
+			indexes[col] = append(indexes[col], idx)
+		}
+	}
+	return indexes
+}
+
+func makeGormTag(c columnMeta, opt options) string {
+	tag := strings.Builder{}
+	if !opt.OmitRedundantColumnTag || opt.ExplicitColumnTags || gormColumnName(c.Field) != c.Name {
+		tag.WriteString("column:")
+		tag.WriteString(c.Name)
+	}
+	if opt.GormType || c.Array {
+		// gorm can't guess the type of an array
+		tag.WriteString(";type:")
+		tag.WriteString(c.Type)
+		if c.Charset != "" {
+			tag.WriteString(";charset:")
+			tag.WriteString(c.Charset)
+		}
+		if c.Collation != "" {
+			tag.WriteString(";collation:")
+			tag.WriteString(c.Collation)
+		}
+	} else if opt.SizeTag && c.Size > 0 {
+		tag.WriteString(";size:")
+		tag.WriteString(strconv.Itoa(c.Size))
+	} else if opt.SizeTag && c.TextType != "" {
+		tag.WriteString(";type:")
+		tag.WriteString(c.TextType)
+	}
+	if c.PrimaryKey {
+		tag.WriteString(";primaryKey")
+	}
+	if c.AutoIncrement {
+		tag.WriteString(";autoIncrement")
+	}
+	if c.ReadOnly {
+		tag.WriteString(";->")
+	}
+	if opt.GormType || opt.DefaultTags {
+		if c.HasDefault && c.DefaultIsString && c.StringType {
+			// gorm trims the quotes, it keeps an empty string and spaces
+			tag.WriteString(";default:'")
+			tag.WriteString(escapeGormValue(c.Default))
+			tag.WriteString("'")
+		} else if c.Default != "" {
+			tag.WriteString(";default:")
+			tag.WriteString(escapeGormValue(c.Default))
+		}
+	} else if c.Default != "" {
+		tag.WriteString(";default:")
+		tag.WriteString(escapeGormValue(c.Default))
+	}
+	if c.Unique {
+		tag.WriteString(";unique")
+	}
+	for _, check := range c.Checks {
+		tag.WriteString(";check:")
+		tag.WriteString(escapeGormValue(check))
+	}
+	// gorm fills time fields named CreatedAt and UpdatedAt without the tag
+	if c.AutoTime != "" && (c.UnixTime != "" || c.Field != "CreatedAt" && c.Field != "UpdatedAt") {
+		if c.AutoTime == "create" {
+			tag.WriteString(";autoCreateTime")
+		} else {
+			tag.WriteString(";autoUpdateTime")
+		}
+		if c.UnixTime == "milli" {
+			tag.WriteString(":milli")
+		}
+	}
+	if opt.CommentTags && c.Comment != "" {
+		tag.WriteString(";comment:")
+		tag.WriteString(escapeGormValue(c.Comment))
+	}
+	for _, idx := range c.Indexes {
+		tag.WriteString(";")
+		tag.WriteString(gormIndexTag(idx))
+	}
+	if c.SoftDelete && len(c.Indexes) == 0 {
+		// queries always filter on deleted_at
+		tag.WriteString(";index")
+	}
+	if !c.PrimaryKey && c.NotNull {
+		tag.WriteString(";NOT NULL")
+	}
+	return strings.TrimPrefix(tag.String(), ";")
+}
+
+// gormInitialisms are replaced by their title case before gorm makes the
+// column name of a field, e.g. "UserID" is "UserId", "HTTPCode" is "HttpCode"
+var gormInitialisms = strings.NewReplacer(
+	"API", "Api", "ASCII", "Ascii", "CPU", "Cpu", "CSS", "Css", "DNS", "Dns",
+	"EOF", "Eof", "GUID", "Guid", "HTML", "Html", "HTTP", "Http", "HTTPS", "Https",
+	"ID", "Id", "IP", "Ip", "JSON", "Json", "LHS", "Lhs", "QPS", "Qps", "RAM", "Ram",
+	"RHS", "Rhs", "RPC", "Rpc", "SLA", "Sla", "SMTP", "Smtp", "SSH", "Ssh",
+	"TLS", "Tls", "TTL", "Ttl", "UID", "Uid", "UI", "Ui", "UUID", "Uuid",
+	"URI", "Uri", "URL", "Url", "UTF8", "Utf8", "VM", "Vm", "XML", "Xml",
+	"XSRF", "Xsrf", "XSS", "Xss",
+)
+
+// gormColumnName is the column name of field by the default NamingStrategy of
+// gorm, it's a copy of NamingStrategy.toDBName in gorm.io/gorm/schema.
+func gormColumnName(name string) string {
+	if name == "" {
+		return ""
+	}
+	var (
+		value                          = gormInitialisms.Replace(name)
+		buf                            strings.Builder
+		lastCase, nextCase, nextNumber bool // upper case == true
+		curCase                        = value[0] <= 'Z' && value[0] >= 'A'
+	)
+	for i, v := range value[:len(value)-1] {
+		nextCase = value[i+1] <= 'Z' && value[i+1] >= 'A'
+		nextNumber = value[i+1] >= '0' && value[i+1] <= '9'
+		if curCase {
+			if lastCase && (nextCase || nextNumber) {
+				buf.WriteRune(v + 32)
+			} else {
+				if i > 0 && value[i-1] != '_' && value[i+1] != '_' {
+					buf.WriteByte('_')
+				}
+				buf.WriteRune(v + 32)
+			}
+		} else {
+			buf.WriteRune(v)
+		}
+		lastCase = curCase
+		curCase = nextCase
+	}
+	if curCase {
+		if !lastCase && len(value) > 1 {
+			buf.WriteByte('_')
+		}
+		buf.WriteByte(value[len(value)-1] + 32)
+	} else {
+		buf.WriteByte(value[len(value)-1])
+	}
+	return buf.String()
+}
+
+// indexName names an unnamed index like the database does, gorm takes the
+// fields of an index without name as indexes of their own. It's the first
+// column with suffix _2, _3 and so on in MySQL, and table_columns_key of
+// unique or table_columns_idx in postgres.
+func indexName(table string, index IndexInfo, used map[string]struct{}, opt options) string {
+	base, sep, n := index.Columns[0], "_", 2
+	if opt.Dialect == DialectPostgres {
+		base = table + "_" + strings.Join(index.Columns, "_") + "_idx"
+		if index.Unique {
+			base = table + "_" + strings.Join(index.Columns, "_") + "_key"
+		}
+		sep, n = "", 1
+	}
+	name := base
+	for {
+		if _, ok := used[strings.ToLower(name)]; !ok {
+			break
+		}
+		name = base + sep + strconv.Itoa(n)
+		n++
+	}
+	used[strings.ToLower(name)] = struct{}{}
+	return name
+}
+
+func gormIndexTag(idx columnIndex) string {
+	tag := "index"
+	if idx.Unique {
+		tag = "uniqueIndex"
+	}
+	settings := make([]string, 0, 2)
+	if idx.Priority > 0 {
+		settings = append(settings, fmt.Sprintf("priority:%d", idx.Priority))
+	}
+	if idx.Fulltext {
+		settings = append(settings, "class:FULLTEXT")
+	} else if idx.Spatial {
+		settings = append(settings, "class:SPATIAL")
+	}
+	tag += ":" + idx.Name
+	if len(settings) > 0 {
+		tag += ","
+	}
+	return tag + strings.Join(settings, ",")
+}
+
+// gormCheck makes the setting of gorm check tag, gorm names the constraint if
+// it's not named.
+func gormCheck(check CheckInfo) string {
+	if check.Name == "" {
+		return check.Expr
+	}
+	return check.Name + "," + check.Expr
+}
+
+// escapeGormValue escapes the separator of gorm tag settings
+func escapeGormValue(s string) string {
+	return strings.ReplaceAll(s, ";", `\;`)
+}
+
+// quotedAtEnds reports whether a string starts or ends with a quote, which is
+// trimmed from a default value by gorm.
+func quotedAtEnds(s string) bool {
+	return s != "" && (strings.IndexByte(`'"`, s[0]) >= 0 || strings.IndexByte(`'"`, s[len(s)-1]) >= 0)
+}
+
+// makeXormTag makes tag of xorm, see https://xorm.io/docs/chapter-02/4.columns/
+func makeXormTag(c columnMeta, opt options) string {
+	parts := make([]string, 0, 4)
+	if opt.GormType {
+		parts = append(parts, c.Type)
+	}
+	if c.PrimaryKey {
+		parts = append(parts, "pk")
+	}
+	if c.AutoIncrement {
+		parts = append(parts, "autoincr")
+	}
+	if c.ReadOnly {
+		parts = append(parts, "<-")
+	}
+	if !c.PrimaryKey && c.NotNull {
+		parts = append(parts, "not null")
+	} else if c.CanNull {
+		parts = append(parts, "null")
+	}
+	if c.Default != "" {
+		if c.DefaultIsString {
+			parts = append(parts, "default "+xormQuote(c.Default))
+		} else {
+			parts = append(parts, "default "+c.Default)
+		}
+	}
+	if c.Unique {
+		parts = append(parts, "unique")
+	}
+	for _, idx := range c.Indexes {
+		tag := "index"
+		if idx.Unique {
+			tag = "unique"
+		}
+		if idx.Name != "" {
+			tag += "(" + idx.Name + ")"
+		}
+		parts = append(parts, tag)
+	}
+	if c.SoftDelete {
+		parts = append(parts, "deleted")
+	}
+	switch c.AutoTime {
+	case "create":
+		parts = append(parts, "created")
+	case "update":
+		parts = append(parts, "updated")
+	}
+	if opt.CommentTags && c.Comment != "" {
+		parts = append(parts, "comment("+xormQuote(c.Comment)+")")
+	}
+	parts = append(parts, xormQuote(c.Name))
+	return strings.Join(parts, " ")
+}
+
+func xormQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// makeValidateTag makes the tag of go-playground/validator. NOT NULL column is
+// required unless it's filled by database or ORM, string column has max length.
+func makeValidateTag(col ColumnInfo, c columnMeta, goType string, opt options) string {
+	rules := make([]string, 0, 2)
+	if c.NotNull && !c.PrimaryKey && !c.AutoIncrement && !c.ReadOnly && c.AutoTime == "" &&
+		(!col.HasDefault || opt.ValidateDefaultRequired) && canRequire(goType) {
+		rules = append(rules, "required")
+	}
+	if (goType == "string" || goType == "*string") && col.Length > 0 {
+		switch col.tp.Tp {
+		case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString:
+			if len(rules) == 0 && goType == "*string" {
+				rules = append(rules, "omitempty")
+			}
+			rules = append(rules, "max="+strconv.Itoa(col.Length))
+		}
+	}
+	return strings.Join(rules, ",")
+}
+
+// canRequire reports whether "required" fits the Go type. The validator
+// rejects zero values, false and 0 are valid values of bool and numbers.
+func canRequire(goType string) bool {
+	return goType == "string" || goType == "time.Time" ||
+		strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "*")
+}
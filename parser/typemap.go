@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/types"
+)
+
+// splitGoType splits a qualified type like "*github.com/shopspring/decimal.Decimal"
+// into "*decimal.Decimal" and import path "github.com/shopspring/decimal".
+// The package name is assumed to be the last element of import path.
+func splitGoType(s string) (goType string, importPath string) {
+	i := 0
+	for i < len(s) && (s[i] == '*' || s[i] == '[' || s[i] == ']') {
+		i++
+	}
+	prefix, name := s[:i], s[i:]
+	dot := strings.LastIndexByte(name, '.')
+	if dot < 0 || !strings.Contains(name[:dot], "/") {
+		// builtin type or a package in standard library, e.g. json.RawMessage
+		// can't tell the import path
+		return s, ""
+	}
+	importPath = name[:dot]
+	pkgName := importPath[strings.LastIndexByte(importPath, '/')+1:]
+	return prefix + pkgName + name[dot:], importPath
+}
+
+// mappedGoType finds the Go type of a column in type mapping by the type
+// declared, e.g. "decimal(18,2)", then by the name of type, e.g. "decimal".
+//
+// DecimalType applies to decimal columns not found in type mapping.
+func mappedGoType(sqlType string, colTp *types.FieldType, opt options) (string, string, bool) {
+	if len(opt.TypeMapping) == 0 {
+		return decimalGoType(colTp, opt)
+	}
+	sqlType = strings.ToLower(sqlType)
+	name := sqlType
+	if i := strings.IndexAny(name, "( "); i >= 0 {
+		name = name[:i]
+	}
+	keys := []string{sqlType}
+	if mysql.HasUnsignedFlag(colTp.Flag) {
+		keys = append(keys, name+" unsigned")
+	}
+	keys = append(keys, name)
+	for _, k := range keys {
+		if t, ok := opt.TypeMapping[k]; ok {
+			goType, pkg := splitGoType(t)
+			return goType, pkg, true
+		}
+	}
+	return decimalGoType(colTp, opt)
+}
+
+func decimalGoType(colTp *types.FieldType, opt options) (string, string, bool) {
+	if opt.DecimalType == "" {
+		return "", "", false
+	}
+	switch colTp.Tp {
+	case mysql.TypeDecimal, mysql.TypeNewDecimal:
+		goType, pkg := splitGoType(opt.DecimalType)
+		return goType, pkg, true
+	}
+	return "", "", false
+}
+
+// uuidGoType gets the UUID type of a column with binary(16), char(36) or uuid
+// type of other dialects. If UUIDColumns is set, the column name must match
+// one of the patterns as well.
+func uuidGoType(col ColumnInfo, opt options) (string, string, bool) {
+	if opt.UUIDType == "" || !isUUIDType(col) {
+		return "", "", false
+	}
+	if len(opt.UUIDColumns) > 0 && !matchColumn(col.Name, opt.UUIDColumns) {
+		return "", "", false
+	}
+	goType, pkg := splitGoType(opt.UUIDType)
+	return goType, pkg, true
+}
+
+func isUUIDType(col ColumnInfo) bool {
+	switch strings.ToLower(col.hint.RawType) {
+	case "uuid", "uniqueidentifier":
+		return true
+	}
+	if col.tp.Tp != mysql.TypeString {
+		return false
+	}
+	if col.tp.Flen == 36 {
+		return true
+	}
+	return col.tp.Flen == 16 && col.tp.Charset == "binary"
+}
+
+// isTinyIntBool reports whether the column is tinyint(1), which is used as bool
+// conventionally, it's enabled by WithTinyIntBool.
+func isTinyIntBool(colTp *types.FieldType, opt options) bool {
+	return opt.TinyIntBool && colTp.Tp == mysql.TypeTiny && colTp.Flen == 1
+}
+
+// autoIncrementType is the type of column with an integer of auto increment
+// made unsigned, the values start at 1. A column referencing such a key takes
+// the type of the key.
+func autoIncrementType(col ColumnInfo) *types.FieldType {
+	if col.refKey != nil {
+		return col.refKey
+	}
+	if !col.AutoIncrement {
+		return col.tp
+	}
+	switch col.tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		tp := *col.tp
+		tp.Flag |= mysql.UnsignedFlag
+		return &tp
+	}
+	return col.tp
+}
+
+// sizedUnsignedType gets the unsigned Go type with the same size of an
+// unsigned integer column.
+func sizedUnsignedType(colTp *types.FieldType) (string, bool) {
+	if !mysql.HasUnsignedFlag(colTp.Flag) {
+		return "", false
+	}
+	switch colTp.Tp {
+	case mysql.TypeTiny:
+		return "uint8", true
+	case mysql.TypeShort:
+		return "uint16", true
+	case mysql.TypeInt24, mysql.TypeLong:
+		return "uint32", true
+	case mysql.TypeLonglong:
+		return "uint64", true
+	}
+	return "", false
+}
+
+var sqlNullTypes = map[string]string{
+	"bool":      "sql.NullBool",
+	"string":    "sql.NullString",
+	"int64":     "sql.NullInt64",
+	"int32":     "sql.NullInt32",
+	"float64":   "sql.NullFloat64",
+	"time.Time": "sql.NullTime",
+}
+
+// nullGoType gets the type of a nullable column from the type used for NOT
+// NULL column. Slices are nullable already, a pointer is used if there is no
+// sql.NullXXX for the type.
+func nullGoType(goType, pkg string, style NullStyle) (string, string) {
+	if style == NullDisable || strings.HasPrefix(goType, "[]") {
+		return goType, pkg
+	}
+	if t, ok := sqlNullTypes[goType]; ok && style == NullInSql {
+		return t, "database/sql"
+	}
+	return "*" + goType, pkg
+}
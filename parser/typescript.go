@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+)
+
+var (
+	tsInterfaceTmpl = template.Must(template.New("tsInterface").Parse(`
+{{- range .Comment -}}
+/** {{.}} */
+{{end -}}
+export interface {{.TableName}} {
+{{- range .Fields}}
+{{- range .Doc}}
+  /** {{.}} */
+{{- end}}
+  {{if .Tag}}readonly {{end}}{{.Name}}: {{.GoType}};
+{{- end}}
+}
+`))
+	tsFileTmpl = template.Must(template.New("tsFile").Parse(fileHeaderTmpl + `{{range .StructCode}}
+{{.}}{{end}}`))
+)
+
+// makeTypeScript makes an interface of TypeScript for table, field names are
+// in camel case.
+func makeTypeScript(table TableInfo, opt options) (string, error) {
+	data := tmplData{
+		TableName: structName(table.Name, opt),
+		Fields:    make([]tmplField, 0, len(table.Columns)),
+		Comment:   commentLines(table.Comment),
+	}
+	for _, col := range table.Columns {
+		name := trimColumnPrefix(col.Name, opt)
+		if matchColumn(name, opt.ExcludeColumns) {
+			continue
+		}
+		field := tmplField{
+			Name:   tsFieldName(name),
+			GoType: tsType(col, opt),
+			Doc:    commentLines(col.Comment),
+		}
+		if col.Generated != "" || table.View {
+			// Tag marks the field read only
+			field.Tag = "readonly"
+		}
+		if col.Nullable {
+			field.GoType += " | null"
+		}
+		data.Fields = append(data.Fields, field)
+	}
+	builder := strings.Builder{}
+	if err := tsInterfaceTmpl.Execute(&builder, data); err != nil {
+		return "", err
+	}
+	return builder.String(), nil
+}
+
+func tsType(col ColumnInfo, opt options) string {
+	var name string
+	switch col.tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
+		mysql.TypeFloat, mysql.TypeDouble, mysql.TypeYear:
+		name = "number"
+	case mysql.TypeBit:
+		name = "number"
+		if col.tp.Flen <= 1 {
+			name = "boolean"
+		}
+	case mysql.TypeSet:
+		name = "string[]"
+	case mysql.TypeTimestamp, mysql.TypeDatetime, mysql.TypeDate:
+		name = "Date"
+	case mysql.TypeEnum:
+		values := make([]string, 0, len(col.Elems))
+		for _, e := range col.Elems {
+			values = append(values, strconv.Quote(e))
+		}
+		name = strings.Join(values, " | ")
+		if col.hint.ArrayDims > 0 || col.nullDeclared {
+			name = "(" + name + ")"
+		}
+	default:
+		// string, decimal, json, time and others are strings in JSON
+		name = "string"
+	}
+	if isTinyIntBool(col.tp, opt) {
+		name = "boolean"
+	}
+	if col.hint.GoType == "[]byte" {
+		name = "string"
+	}
+	return name + strings.Repeat("[]", col.hint.ArrayDims)
+}
+
+// tsFieldName converts a column name to lower camel case
+func tsFieldName(column string) string {
+	name := toCamel(column)
+	if name == "" {
+		return column
+	}
+	// lower the leading acronym, e.g. ID to id and IPAddress to ipAddress
+	n := 0
+	for n < len(name) && name[n] >= 'A' && name[n] <= 'Z' {
+		n++
+	}
+	if n > 1 && n < len(name) && name[n] >= 'a' && name[n] <= 'z' {
+		n--
+	}
+	if n == 0 {
+		n = 1
+	}
+	return strings.ToLower(name[:n]) + name[n:]
+}
@@ -0,0 +1,64 @@
+package parser
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSqlTypeScript(t *testing.T) {
+	sql := `CREATE TABLE users (
+  id bigint NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  ip_address varchar(45) NOT NULL COMMENT 'last login ip',
+  status enum('active','banned') NOT NULL,
+  created_at datetime NULL,
+  balance decimal(10,2) NULL
+) COMMENT 'all users';`
+	buf := bytes.Buffer{}
+	err := ParseSqlToWrite(sql, &buf, WithTarget(TargetTypeScript))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, `// Code generated by github.com/cascax/sql2gorm. DO NOT EDIT.
+
+/** all users */
+export interface Users {
+  id: number;
+  /** last login ip */
+  ipAddress: string;
+  status: "active" | "banned";
+  createdAt: Date | null;
+  balance: string | null;
+}
+`, buf.String())
+
+	// columns are nullable without NULL, whatever the null style of Go
+	data, err := ParseSql(`CREATE TABLE t (a int, b int NOT NULL);`, WithTarget(TargetTypeScript), WithNoNullType())
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "  a: number | null;\n  b: number;\n")
+		assert.Empty(t, data.ImportPath)
+	}
+}
+
+func TestParseSqlTypeScriptPostgres(t *testing.T) {
+	sql := `CREATE TABLE posts (id serial PRIMARY KEY, tags text[] NULL, body bytea NOT NULL);`
+	data, err := ParseSql(sql, WithTarget(TargetTypeScript), WithDialect(DialectPostgres))
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(data.StructCode)) {
+		return
+	}
+	assert.Contains(t, data.StructCode[0], "  tags: string[] | null;\n")
+	assert.Contains(t, data.StructCode[0], "  body: string;\n")
+
+	data, err = ParseSql(`CREATE TABLE t (a int, b int AS (a + 1) VIRTUAL);`, WithTarget(TargetTypeScript))
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(data.StructCode)) {
+		assert.Contains(t, data.StructCode[0], "  a: number | null;\n  readonly b: number | null;\n")
+	}
+}
+
+func TestTsFieldName(t *testing.T) {
+	assert.Equal(t, "id", tsFieldName("id"))
+	assert.Equal(t, "userID", tsFieldName("user_id"))
+	assert.Equal(t, "ipAddress", tsFieldName("ip_address"))
+	assert.Equal(t, "createdAt", tsFieldName("created_at"))
+}
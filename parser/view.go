@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/knocknote/vitess-sqlparser/tidbparser/ast"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/mysql"
+	"github.com/knocknote/vitess-sqlparser/tidbparser/dependency/types"
+)
+
+// viewSource is a table in FROM of a view
+type viewSource struct {
+	name     string // alias or name of table in lower case
+	table    *TableInfo
+	nullable bool // columns may be NULL by an outer join
+}
+
+// newViewInfo makes a read only table of CREATE VIEW, columns are copied from
+// the tables created before. A column of expression is skipped since its type
+// is unknown, except COUNT with an alias.
+func newViewInfo(stmt *ast.CreateViewStmt, tables []TableInfo, ctx *parseContext) TableInfo {
+	view := TableInfo{Name: stmt.ViewName.Name.String(), Schema: stmt.ViewName.Schema.String(), View: true}
+	sel, ok := stmt.Select.(*ast.SelectStmt)
+	if !ok || sel.Fields == nil {
+		ctx.warn(view.Name, "", "columns of the view are unknown")
+		return view
+	}
+	var sources []viewSource
+	if sel.From != nil {
+		sources = viewSources(sel.From.TableRefs, tables, false, sources)
+	}
+	n := 0
+	// name is the column name of view list or alias, empty if not given
+	name := func(alias string) string {
+		if n < len(stmt.Cols) {
+			alias = stmt.Cols[n].O
+		}
+		n++
+		return alias
+	}
+	for _, f := range sel.Fields.Fields {
+		if f.WildCard != nil {
+			for _, s := range sources {
+				if f.WildCard.Table.L != "" && f.WildCard.Table.L != s.name {
+					continue
+				}
+				for _, c := range s.table.Columns {
+					c = viewColumn(c, s.nullable)
+					if alias := name(""); alias != "" {
+						c.Name = alias
+					}
+					view.Columns = append(view.Columns, c)
+				}
+			}
+			continue
+		}
+		alias := name(f.AsName.O)
+		c, ok := viewExprColumn(f.Expr, sources)
+		if ok && alias != "" {
+			c.Name = alias
+		}
+		if !ok || c.Name == "" {
+			label := alias
+			if label == "" {
+				label = "#" + strconv.Itoa(n)
+			}
+			ctx.warn(view.Name, label, "the column is skipped, its type is unknown")
+			continue
+		}
+		view.Columns = append(view.Columns, c)
+	}
+	return view
+}
+
+// viewSources collects the tables of FROM, a table not created before is not
+// collected.
+func viewSources(node ast.ResultSetNode, tables []TableInfo, nullable bool, sources []viewSource) []viewSource {
+	switch node := node.(type) {
+	case *ast.Join:
+		sources = viewSources(node.Left, tables, nullable || node.Tp == ast.RightJoin, sources)
+		if node.Right != nil {
+			sources = viewSources(node.Right, tables, nullable || node.Tp == ast.LeftJoin, sources)
+		}
+	case *ast.TableSource:
+		name, ok := node.Source.(*ast.TableName)
+		if !ok {
+			return sources
+		}
+		for i := len(tables) - 1; i >= 0; i-- {
+			if !strings.EqualFold(tables[i].Name, name.Name.O) {
+				continue
+			}
+			s := viewSource{name: node.AsName.L, table: &tables[i], nullable: nullable}
+			if s.name == "" {
+				s.name = name.Name.L
+			}
+			sources = append(sources, s)
+			break
+		}
+	}
+	return sources
+}
+
+// viewExprColumn gets the column of an expression in the select of view
+func viewExprColumn(expr ast.ExprNode, sources []viewSource) (ColumnInfo, bool) {
+	switch expr := expr.(type) {
+	case *ast.ParenthesesExpr:
+		return viewExprColumn(expr.Expr, sources)
+	case *ast.ColumnNameExpr:
+		for _, s := range sources {
+			if expr.Name.Table.L != "" && expr.Name.Table.L != s.name {
+				continue
+			}
+			if i := findColumn(s.table, expr.Name.Name.O); i >= 0 {
+				return viewColumn(s.table.Columns[i], s.nullable), true
+			}
+		}
+	case *ast.AggregateFuncExpr:
+		if strings.EqualFold(expr.F, ast.AggFuncCount) {
+			tp := types.NewFieldType(mysql.TypeLonglong)
+			tp.Flen = 21
+			return ColumnInfo{
+				Type:    "bigint(21)",
+				Length:  tp.Flen,
+				Scale:   tp.Decimal,
+				NotNull: true,
+				tp:      tp,
+			}, true
+		}
+	}
+	return ColumnInfo{}, false
+}
+
+// viewColumn copies a column of table to view, keys and defaults of the table
+// don't apply to the view.
+func viewColumn(c ColumnInfo, nullable bool) ColumnInfo {
+	if c.PrimaryKey {
+		c.NotNull = true
+	}
+	if nullable {
+		c.NotNull = false
+	}
+	c.PrimaryKey, c.AutoIncrement, c.Unique = false, false, false
+	c.HasDefault, c.Default, c.DefaultIsString, c.OnUpdate = false, "", false, ""
+	c.nullDeclared = nullable || c.nullDeclared && !c.NotNull
+	c.Nullable = !c.NotNull
+	return c
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"fmt"
+	"log"
+)
+
+// Warning is something of the input dropped or guessed during parsing, e.g. an
+// unsupported statement or an unknown type mapped to text.
+type Warning struct {
+	Table   string // empty if it's not about a table
+	Column  string // empty if it's not about a column
+	Message string
+}
+
+func (w Warning) String() string {
+	switch {
+	case w.Column != "":
+		return w.Table + "." + w.Column + ": " + w.Message
+	case w.Table != "":
+		return w.Table + ": " + w.Message
+	}
+	return w.Message
+}
+
+func newWarning(table, column, format string, args ...interface{}) Warning {
+	return Warning{Table: table, Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// logWarnings writes warnings by the standard logger for functions which
+// don't return them.
+func logWarnings(warnings []Warning) {
+	for _, w := range warnings {
+		log.Printf("sql2gorm: %s", w)
+	}
+}
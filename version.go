@@ -0,0 +1,31 @@
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// version and commit are set by -ldflags, e.g.
+// -ldflags "-X main.version=v1.2.0 -X main.commit=5f3c2e1". Without them the
+// version of module is read from build info, which is set by go install.
+var (
+	version = ""
+	commit  = ""
+)
+
+// versionString is written by -version, e.g. "sql2gorm v1.2.0 (5f3c2e1) go1.16.5"
+func versionString() string {
+	v := version
+	if v == "" {
+		if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+			v = info.Main.Version
+		} else {
+			v = "(devel)"
+		}
+	}
+	s := "sql2gorm " + v
+	if commit != "" {
+		s += " (" + commit + ")"
+	}
+	return s + " " + runtime.Version()
+}
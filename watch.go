@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/cascax/sql2gorm/parser"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDelay merges writes in a short time, editors may write a file several
+// times when saving
+const watchDelay = 200 * time.Millisecond
+
+// watch generates code whenever input files change until SIGINT, errors are
+// printed without exiting
+func watch(args options, opt []parser.Option) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		exitWithInfo("watch failed, %s", err)
+	}
+	defer watcher.Close()
+	// watch directories since editors may replace the file by renaming
+	dirs := make(map[string]struct{})
+	for _, f := range args.InputFiles {
+		dir := filepath.Dir(f)
+		if _, ok := dirs[dir]; ok {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			exitWithInfo("watch %s failed, %s", dir, err)
+		}
+		dirs[dir] = struct{}{}
+	}
+
+	generate := func() {
+		now := time.Now().Format("15:04:05")
+		if err := generateFromFiles(args, opt); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s %s\n", now, err)
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "%s generated\n", now)
+		}
+	}
+	generate()
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	var delay <-chan time.Time
+	for {
+		select {
+		case e, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if e.Op != fsnotify.Chmod && isInputFile(args.InputFiles, e.Name) {
+				delay = time.After(watchDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "watch error: %s\n", err)
+		case <-delay:
+			delay = nil
+			generate()
+		case <-sig:
+			return
+		}
+	}
+}
+
+// isInputFile reports whether name is one of the files or matches a glob
+func isInputFile(files []string, name string) bool {
+	name = filepath.Clean(name)
+	for _, f := range files {
+		if ok, _ := filepath.Match(filepath.Clean(f), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// generateFromFiles reads input files and writes code to the output
+func generateFromFiles(args options, opt []parser.Option) error {
+	files, err := parser.ReadSqlFiles(args.InputFiles)
+	if err != nil {
+		return err
+	}
+	if args.OutputDir != "" {
+		return files.Locate(parser.ParseSqlToFiles(files.Sql, args.OutputDir, opt...))
+	}
+	buf := bytes.Buffer{}
+	err = parser.ParseSqlToWrite(files.Sql, &buf, opt...)
+	if err != nil && buf.Len() == 0 {
+		return files.Locate(err)
+	}
+	if args.OutputFile == "" {
+		_, _ = os.Stdout.Write(buf.Bytes())
+	} else if err := ioutil.WriteFile(args.OutputFile, buf.Bytes(), 0666); err != nil {
+		return err
+	}
+	return files.Locate(err)
+}